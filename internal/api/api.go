@@ -3,24 +3,50 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
 	"github.com/kregan77/dartbuddy/internal/model"
+	"github.com/kregan77/dartbuddy/internal/model/notation"
+	"github.com/kregan77/dartbuddy/internal/model/oh1"
 )
 
 // Server holds the HTTP server and game state
 type Server struct {
 	games map[uuid.UUID]*GameState
-	mu    sync.RWMutex
+	mu    sync.RWMutex // guards games; each game has its own lock
 }
 
-// GameState wraps a Game01 with additional metadata
+// GameState wraps an oh1.Game with additional metadata.
+// Handlers hold mu for as long as they read or change the game.
 type GameState struct {
-	Game       *model.Game01
+	mu         sync.Mutex
+	Game       *oh1.Game
 	IsRealGame bool // true if any real players are in the game
-	OutChart   *model.OutChart
+	OutChart   *oh1.OutChart
+	PlayerOuts bool                                     // true if every player gets checkouts tailored to their accuracy
+	Estimators map[uuid.UUID]*model.DispersionEstimator // learned dispersion of real players, by player ID
+	Histories  map[uuid.UUID]*model.ThrowHistory        // recorded darts of real players, by player ID
+	tailoredTo map[uuid.UUID]int                        // records each real player's checkouts were last tailored to
+	LegDarts   []oh1.DartThrown                         // every dart of the leg so far, in order
+	turnStart  int                                      // index in LegDarts of the latest turn's first dart
+}
+
+// recordEvent keeps the leg's darts for drawing the board
+func (gs *GameState) recordEvent(e oh1.Event) {
+	switch e := e.(type) {
+	case oh1.TurnStarted:
+		gs.turnStart = len(gs.LegDarts)
+	case oh1.DartThrown:
+		gs.LegDarts = append(gs.LegDarts, e)
+	}
 }
 
 // NewServer creates a new API server
@@ -32,22 +58,123 @@ func NewServer() *Server {
 
 // CreateGameRequest represents a request to create a new game
 type CreateGameRequest struct {
-	StartingScore int  `json:"starting_score"`
-	UseOutChart   bool `json:"use_out_chart"` // if true, players use the out chart
+	StartingScore  int    `json:"starting_score"`
+	UseOutChart    bool   `json:"use_out_chart"`   // if true, players use the out chart
+	PlayerOuts     bool   `json:"player_outs"`     // if true, each player gets checkouts tailored to their accuracy
+	Seed           *int64 `json:"seed,omitempty"`  // optional simulator seed for a reproducible game
+	RealisticWires bool   `json:"realistic_wires"` // if true, simulate wire bounce-outs and dart shadowing
+	Board          string `json:"board,omitempty"` // "steel" (default), "soft" or "quadro"
 }
 
 // CreateGameResponse represents the response from creating a game
 type CreateGameResponse struct {
 	GameID        string `json:"game_id"`
 	StartingScore int    `json:"starting_score"`
+	Seed          int64  `json:"seed"`
 }
 
 // AddPlayerRequest represents a request to add a player
 type AddPlayerRequest struct {
-	Name              string  `json:"name"`
-	IsSimulated       bool    `json:"is_simulated"`
-	ThreeDA           float64 `json:"three_da"`           // Only for simulated players
-	ScoringPreference string  `json:"scoring_preference"` // "twenties" or "nineteens"
+	Name              string             `json:"name"`
+	IsSimulated       bool               `json:"is_simulated"`
+	ThreeDA           float64            `json:"three_da"`                    // Only for simulated players
+	ScoringPreference string             `json:"scoring_preference"`          // "twenties", "nineteens", "eighteens", "bull" or "cover"
+	ThrowModel        *ThrowModelRequest `json:"throw_model,omitempty"`       // Only for simulated players
+	AimBias           *AimBiasData       `json:"aim_bias,omitempty"`          // Only for simulated players
+	OptimalAim        bool               `json:"optimal_aim"`                 // Only for simulated players
+	Performance       string             `json:"performance,omitempty"`       // "none", "steady", "standard" or "streaky"; only for simulated players
+	PreferredDoubles  []int              `json:"preferred_doubles,omitempty"` // Finishing doubles from most to least preferred; 25 is the bull
+	RouteOverrides    map[int]string     `json:"route_overrides,omitempty"`   // Checkout routes by score, e.g. {"81": "T19 D12"}
+}
+
+// AimBiasData is a simulated player's systematic drift in mm (x right, y up)
+type AimBiasData struct {
+	X       float64          `json:"x"`
+	Y       float64          `json:"y"`
+	Regions []RegionBiasData `json:"regions,omitempty"`
+}
+
+// RegionBiasData overrides the bias for a board region; zero multiplier or number matches any
+type RegionBiasData struct {
+	Multiplier int     `json:"multiplier"`
+	Number     int     `json:"number"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+}
+
+// toAimBias converts the request data into a model.AimBias
+func (d *AimBiasData) toAimBias() model.AimBias {
+	bias := model.AimBias{X: d.X, Y: d.Y}
+	for _, r := range d.Regions {
+		bias.Regions = append(bias.Regions, model.RegionBias{
+			Multiplier: model.Multiplier(r.Multiplier),
+			Number:     r.Number,
+			X:          r.X,
+			Y:          r.Y,
+		})
+	}
+	return bias
+}
+
+// ThrowModelRequest selects and parameterises a simulated player's throw dispersion model
+type ThrowModelRequest struct {
+	Kind             string   `json:"kind"`                         // "gaussian", "bivariate", "student_t" or "mixture"
+	HorizontalScale  float64  `json:"horizontal_scale,omitempty"`   // bivariate: sigma_x relative to spread
+	VerticalScale    float64  `json:"vertical_scale,omitempty"`     // bivariate: sigma_y relative to spread
+	Correlation      float64  `json:"correlation,omitempty"`        // bivariate: x/y correlation, strictly between -1 and 1
+	DegreesOfFreedom float64  `json:"degrees_of_freedom,omitempty"` // student_t: tail heaviness, above 2
+	BadDartChance    *float64 `json:"bad_dart_chance,omitempty"`    // mixture: probability of a bad dart; 0.15 if omitted
+	BadDartScale     float64  `json:"bad_dart_scale,omitempty"`     // mixture: spread multiplier for bad darts
+}
+
+// toThrowModel converts the request into a model.ThrowModel
+func (r *ThrowModelRequest) toThrowModel() (model.ThrowModel, error) {
+	switch r.Kind {
+	case "", "gaussian":
+		return model.GaussianThrowModel{}, nil
+	case "bivariate":
+		h, v := r.HorizontalScale, r.VerticalScale
+		if h < 0 || v < 0 {
+			return nil, fmt.Errorf("horizontal_scale and vertical_scale must be positive, got %v and %v", h, v)
+		}
+		if r.Correlation <= -1 || r.Correlation >= 1 {
+			return nil, fmt.Errorf("correlation must be between -1 and 1, got %v", r.Correlation)
+		}
+		if h == 0 {
+			h = 1.0
+		}
+		if v == 0 {
+			v = 1.0
+		}
+		return model.NewBivariateGaussianThrowModel(h, v, r.Correlation), nil
+	case "student_t":
+		dof := r.DegreesOfFreedom
+		if dof == 0 {
+			dof = 4.0
+		}
+		if dof <= 2 {
+			// the spread is a standard deviation, which only exists above 2 degrees of freedom
+			return nil, fmt.Errorf("degrees_of_freedom must be above 2, got %v", dof)
+		}
+		return model.NewStudentTThrowModel(dof), nil
+	case "mixture":
+		chance, scale := 0.15, r.BadDartScale
+		if r.BadDartChance != nil {
+			chance = *r.BadDartChance
+		}
+		if chance < 0 || chance > 1 {
+			return nil, fmt.Errorf("bad_dart_chance must be between 0 and 1, got %v", chance)
+		}
+		if scale < 0 {
+			return nil, fmt.Errorf("bad_dart_scale must be positive, got %v", scale)
+		}
+		if scale == 0 {
+			scale = 2.5
+		}
+		return model.NewGoodBadThrowModel(chance, scale), nil
+	default:
+		return nil, fmt.Errorf("unknown throw model %q", r.Kind)
+	}
 }
 
 // AddPlayerResponse represents the response from adding a player
@@ -56,14 +183,68 @@ type AddPlayerResponse struct {
 	Name     string `json:"name"`
 }
 
+// TargetAccuracyData is a player's spread multiplier for a single target
+type TargetAccuracyData struct {
+	Multiplier   int     `json:"multiplier"`
+	Number       int     `json:"number"`
+	SpreadFactor float64 `json:"spread_factor"` // below 1 is more accurate than usual, above 1 less
+}
+
+// AccuracyProfileData is a player's per-target accuracy profile
+type AccuracyProfileData struct {
+	Targets []TargetAccuracyData `json:"targets"`
+}
+
 // SubmitScoreRequest represents a real player submitting their score
 type SubmitScoreRequest struct {
-	Scores []int `json:"scores"` // Array of 1-3 scores for the turn
+	Scores []int  `json:"scores"`          // Array of 1-3 scores for the turn
+	Darts  string `json:"darts,omitempty"` // the turn dart by dart in shorthand, e.g. "T20 T20 D20"; scored with bust and double-out rules
+}
+
+// SubmitCoordinatesRequest represents darts reported as landing positions, e.g. by an autoscoring camera
+type SubmitCoordinatesRequest struct {
+	Darts           []CoordinateData `json:"darts"`            // 1-3 landing positions for the turn
+	Normalised      bool             `json:"normalised"`       // if true, coordinates are fractions of the board radius instead of mm
+	InvertY         bool             `json:"invert_y"`         // if true, y grows downwards as in image coordinates
+	RotationDegrees float64          `json:"rotation_degrees"` // clockwise angle from the top of the frame to the 20
+}
+
+// CoordinateData is a single dart's landing position
+type CoordinateData struct {
+	X      float64     `json:"x"`
+	Y      float64     `json:"y"`
+	Target *TargetData `json:"target,omitempty"` // what the player aimed at, if known; used to learn their dispersion
+}
+
+// TargetData identifies a bed on the board
+type TargetData struct {
+	Multiplier int `json:"multiplier"`
+	Number     int `json:"number"`
+}
+
+// GhostRequest represents a request to add a simulated copy of a real player to the game
+type GhostRequest struct {
+	Name              string `json:"name"`
+	Kind              string `json:"kind"`               // "parametric" (default) samples the learned dispersion, "replay" samples recorded darts
+	ScoringPreference string `json:"scoring_preference"` // "twenties", "nineteens", "eighteens", "bull" or "cover"
+}
+
+// DispersionEstimateData represents what has been learned about a player's throwing
+type DispersionEstimateData struct {
+	PlayerID   string  `json:"player_id"`
+	Darts      int     `json:"darts"`
+	Spread     float64 `json:"spread"`      // posterior mean, mm
+	SpreadLow  float64 `json:"spread_low"`  // 90% credible interval, mm
+	SpreadHigh float64 `json:"spread_high"` // 90% credible interval, mm
+	BiasX      float64 `json:"bias_x"`
+	BiasY      float64 `json:"bias_y"`
+	GhostID    string  `json:"ghost_id,omitempty"` // set when a ghost was added to the game
 }
 
 // GameStateResponse represents the current state of the game
 type GameStateResponse struct {
 	GameID         string          `json:"game_id"`
+	Seed           int64           `json:"seed"`
 	Turn           int             `json:"turn"`
 	CurrentPlayer  PlayerState     `json:"current_player"`
 	Players        []PlayerState   `json:"players"`
@@ -82,14 +263,58 @@ type PlayerState struct {
 	Turns        int     `json:"turns"`
 	TotalPoints  int     `json:"total_points"`
 	AverageScore float64 `json:"average_score"`
+	Checkout     string  `json:"checkout,omitempty"`      // suggested checkout in shorthand, e.g. "T20 T10 D16"
+	CheckoutOdds float64 `json:"checkout_odds,omitempty"` // chance of taking it this turn, with tailored checkouts
+	Setup        string  `json:"setup,omitempty"`         // with no checkout, darts that set one up, e.g. "T20 T20 S20"
+	SetupLeaves  int     `json:"setup_leaves,omitempty"`  // the score the setup leaves
 }
 
 // TurnResultData represents the result of a turn
 type TurnResultData struct {
-	PlayerName     string  `json:"player_name"`
-	TotalScore     int     `json:"total_score"`
-	RemainingScore int     `json:"remaining_score"`
-	ThreeDA        float64 `json:"three_da"`
+	PlayerName     string     `json:"player_name"`
+	TotalScore     int        `json:"total_score"`
+	RemainingScore int        `json:"remaining_score"`
+	ThreeDA        float64    `json:"three_da"`
+	Darts          []DartData `json:"darts,omitempty"` // only for simulated or coordinate-scored turns
+}
+
+// DartData represents a single dart of a turn, with where it was aimed and where it landed.
+// Coordinates are in mm from the centre of the board, x to the right and y up;
+// angles are in radians clockwise from the 20.
+type DartData struct {
+	Notation     string   `json:"notation"` // shorthand, e.g. T20
+	Multiplier   string   `json:"multiplier"`
+	Number       int      `json:"number"`
+	Score        int      `json:"score"`
+	BounceOut    bool     `json:"bounce_out,omitempty"`
+	X            float64  `json:"x"`
+	Y            float64  `json:"y"`
+	Radius       float64  `json:"radius"`
+	Angle        float64  `json:"angle"`
+	AimX         *float64 `json:"aim_x,omitempty"` // aim fields are only known for simulated darts
+	AimY         *float64 `json:"aim_y,omitempty"`
+	MissDistance *float64 `json:"miss_distance,omitempty"`
+}
+
+// newDartData converts a dart result into its API representation
+func newDartData(d *model.DartResult) DartData {
+	data := DartData{
+		Notation:   notation.Format(d.DartTarget),
+		Multiplier: d.GetMultiplier().String(),
+		Number:     d.GetNumber(),
+		Score:      d.Score,
+		BounceOut:  d.BounceOut,
+		X:          d.Landing.X,
+		Y:          d.Landing.Y,
+		Radius:     d.Landing.Radius,
+		Angle:      d.Landing.Angle,
+	}
+	if miss, ok := d.MissDistance(); ok {
+		data.AimX = &d.Aim.X
+		data.AimY = &d.Aim.Y
+		data.MissDistance = &miss
+	}
+	return data
 }
 
 // CreateGame handles POST /games
@@ -110,14 +335,31 @@ func (s *Server) CreateGame(w http.ResponseWriter, r *http.Request) {
 		req.StartingScore = 501
 	}
 
-	game := model.New01Game(req.StartingScore)
+	spec, ok := model.BoardSpecByName(req.Board)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown board %q", req.Board), http.StatusBadRequest)
+		return
+	}
+
+	seed := rand.Int63()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	game := oh1.New01GameWithSimulator(req.StartingScore, model.NewBoardSimulator(spec, seed))
+	if req.RealisticWires {
+		game.Simulator.SetWireConfig(model.StandardWireConfig())
+	}
+	game.AddObserver(oh1.NewSlogObserver(slog.Default().With("game", game.ID.String()), slog.LevelDebug))
 	gameState := &GameState{
 		Game:       game,
 		IsRealGame: false,
+		PlayerOuts: req.PlayerOuts,
 	}
+	game.AddObserver(oh1.ObserverFunc(gameState.recordEvent))
 
 	if req.UseOutChart {
-		gameState.OutChart = model.NewOutChart()
+		gameState.OutChart = oh1.NewOutChart()
 	}
 
 	s.mu.Lock()
@@ -127,6 +369,7 @@ func (s *Server) CreateGame(w http.ResponseWriter, r *http.Request) {
 	resp := CreateGameResponse{
 		GameID:        game.ID.String(),
 		StartingScore: req.StartingScore,
+		Seed:          game.Seed,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -158,6 +401,48 @@ func (s *Server) AddPlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	performance, ok := oh1.PerformanceByName(req.Performance)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown performance model %q", req.Performance), http.StatusBadRequest)
+		return
+	}
+
+	pref, ok := model.ScoringPreferenceByName(req.ScoringPreference)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown scoring preference %q", req.ScoringPreference), http.StatusBadRequest)
+		return
+	}
+	for _, d := range req.PreferredDoubles {
+		if (d < 1 || d > 20) && d != model.Bullseye {
+			http.Error(w, fmt.Sprintf("Invalid preferred double %d", d), http.StatusBadRequest)
+			return
+		}
+	}
+	var overrides map[int][]model.DartTarget
+	for score, route := range req.RouteOverrides {
+		targets, err := notation.ParseTurn(route)
+		if err == nil {
+			err = oh1.ValidateRoute(score, targets)
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid route for %d: %v", score, err), http.StatusBadRequest)
+			return
+		}
+		if overrides == nil {
+			overrides = make(map[int][]model.DartTarget)
+		}
+		overrides[score] = targets
+	}
+
+	var throwModel model.ThrowModel
+	if req.ThrowModel != nil {
+		throwModel, err = req.ThrowModel.toThrowModel()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid throw model: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
 	s.mu.Lock()
 	gameState, exists := s.games[gameID]
 	s.mu.Unlock()
@@ -166,14 +451,8 @@ func (s *Server) AddPlayer(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Game not found", http.StatusNotFound)
 		return
 	}
-
-	// Parse scoring preference
-	var pref model.ScoringPreference
-	if req.ScoringPreference == "nineteens" {
-		pref = model.NinteensScoringPreference
-	} else {
-		pref = model.TwentiesScoringPreference
-	}
+	gameState.mu.Lock()
+	defer gameState.mu.Unlock()
 
 	// For simulated players, use the provided 3DA
 	// For real players, use a default 3DA (won't be used for targeting)
@@ -188,7 +467,21 @@ func (s *Server) AddPlayer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	profile := model.NewPlayer(req.Name, threeDA, pref)
-	gameState.Game.AddPlayer(profile)
+	if req.IsSimulated {
+		profile.PlayerType = model.SimulatedPlayer
+	}
+	profile.ThrowModel = throwModel
+	profile.OptimalAim = req.OptimalAim
+	if req.AimBias != nil {
+		profile.AimBias = req.AimBias.toAimBias()
+	}
+	profile.PreferredDoubles = req.PreferredDoubles
+	profile.RouteOverrides = overrides
+	player := gameState.Game.AddPlayer(profile)
+	player.Performance = performance
+	if gameState.PlayerOuts {
+		gameState.Game.UsePlayerOuts(player)
+	}
 
 	resp := AddPlayerResponse{
 		PlayerID: profile.ID.String(),
@@ -226,15 +519,21 @@ func (s *Server) PlaySimulatedTurn(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Game not found", http.StatusNotFound)
 		return
 	}
+	gameState.mu.Lock()
+	defer gameState.mu.Unlock()
 
 	if err := gameState.Game.Start(); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to start game: %v", err), http.StatusBadRequest)
 		return
 	}
 
-	result, won := gameState.Game.PlayTurn()
+	result, err := gameState.Game.PlayTurn()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Cannot play turn: %v", err), http.StatusConflict)
+		return
+	}
 
-	resp := s.buildGameStateResponse(gameState, result, won)
+	resp := s.buildGameStateResponse(gameState, result)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
@@ -273,14 +572,47 @@ func (s *Server) SubmitScore(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Game not found", http.StatusNotFound)
 		return
 	}
+	gameState.mu.Lock()
+	defer gameState.mu.Unlock()
 
 	if err := gameState.Game.Start(); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to start game: %v", err), http.StatusBadRequest)
 		return
 	}
 
-	// Process the submitted scores
+	if gameState.Game.Finished {
+		http.Error(w, "The leg is over", http.StatusConflict)
+		return
+	}
+
 	player := gameState.Game.GetCurrentPlayer()
+	if player.GetType() != model.RealPlayer {
+		http.Error(w, "Current player is not a real player", http.StatusConflict)
+		return
+	}
+	if gameState.PlayerOuts {
+		gameState.refreshPlayerOuts(player)
+	}
+
+	if req.Darts != "" {
+		darts, err := parseDarts(req.Darts, gameState.Game.Simulator.GetBoardSpec())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid darts: %v", err), http.StatusBadRequest)
+			return
+		}
+		result, err := gameState.Game.SubmitDarts(darts)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to submit darts: %v", err), http.StatusBadRequest)
+			return
+		}
+		resp := s.buildGameStateResponse(gameState, result)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	// Process the submitted scores
 	totalScore := 0
 	won := false
 
@@ -296,24 +628,506 @@ func (s *Server) SubmitScore(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	result := &model.TurnResult{
-		PlayerName:     player.Profile.GetName(),
+	result := &oh1.TurnResult{
+		PlayerName:     player.GetName(),
 		TotalScore:     totalScore,
 		RemainingScore: player.CurrentScore,
-		ThreeDA:        float64(totalScore) / float64(len(req.Scores)),
+		CurrentThreeDA: player.CurrentThreeDA(),
 	}
 
-	if !won {
+	if won {
+		gameState.Game.Finish(player)
+	} else {
 		gameState.Game.Turn++
 		gameState.Game.NextPlayer()
 	}
 
-	resp := s.buildGameStateResponse(gameState, result, won)
+	resp := s.buildGameStateResponse(gameState, result)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
+// parseDarts reads a turn written in shorthand and scores it, rejecting beds the board doesn't have
+func parseDarts(turn string, spec *model.BoardSpec) ([]*model.DartResult, error) {
+	targets, err := notation.ParseTurn(turn)
+	if err != nil {
+		return nil, err
+	}
+	darts := make([]*model.DartResult, len(targets))
+	for i, t := range targets {
+		if t.Multiplier == model.Miss {
+			darts[i] = &model.DartResult{DartTarget: t}
+			continue
+		}
+		if err := validateBed(t, spec); err != nil {
+			return nil, fmt.Errorf("dart %d: %v", i+1, err)
+		}
+		darts[i] = &model.DartResult{DartTarget: t, Score: t.Number * int(t.Multiplier)}
+	}
+	return darts, nil
+}
+
+// validateBed checks that t is a scoring bed on the board
+func validateBed(t model.DartTarget, spec *model.BoardSpec) error {
+	switch {
+	case t.Multiplier < model.Single || t.Multiplier > model.Quadruple:
+		return fmt.Errorf("%d is not a multiplier", t.Multiplier)
+	case t.Number == model.Bullseye:
+		if t.Multiplier != model.Single && t.Multiplier != model.Double {
+			return fmt.Errorf("%s is not a bull", notation.Format(t))
+		}
+	case t.Number < 1 || t.Number > 20:
+		return fmt.Errorf("%d is not a number on the board", t.Number)
+	case t.Multiplier == model.Single:
+	default:
+		if _, ok := spec.GetRing(t.Multiplier); !ok {
+			return fmt.Errorf("%s is not on a %s board", notation.Format(t), spec.Name)
+		}
+	}
+	return nil
+}
+
+// SubmitCoordinates handles POST /games/{id}/turns/coordinates
+func (s *Server) SubmitCoordinates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract game ID from URL path
+	gameIDStr := r.URL.Path[len("/games/"):]
+	if idx := len(gameIDStr) - len("/turns/coordinates"); idx > 0 {
+		gameIDStr = gameIDStr[:idx]
+	}
+
+	gameID, err := uuid.Parse(gameIDStr)
+	if err != nil {
+		http.Error(w, "Invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req SubmitCoordinatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	gameState, exists := s.games[gameID]
+	s.mu.Unlock()
+
+	if !exists {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+	gameState.mu.Lock()
+	defer gameState.mu.Unlock()
+
+	if err := gameState.Game.Start(); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to start game: %v", err), http.StatusBadRequest)
+		return
+	}
+	if gameState.Game.Finished {
+		http.Error(w, "The leg is over", http.StatusConflict)
+		return
+	}
+	player := gameState.Game.GetCurrentPlayer()
+	if player.GetType() != model.RealPlayer {
+		http.Error(w, "Current player is not a real player", http.StatusConflict)
+		return
+	}
+
+	// Score the landing positions on the game's board
+	scorer := model.NewScorer(gameState.Game.Simulator.GetBoardSpec())
+	scorer.Normalised = req.Normalised
+	scorer.InvertY = req.InvertY
+	scorer.Rotation = req.RotationDegrees * math.Pi / 180.0
+
+	if len(req.Darts) == 0 || len(req.Darts) > 3 {
+		http.Error(w, fmt.Sprintf("Invalid darts: a turn has 1 to 3 darts, got %d", len(req.Darts)), http.StatusBadRequest)
+		return
+	}
+	darts := make([]*model.DartResult, len(req.Darts))
+	var records []model.ThrowRecord
+	for i, c := range req.Darts {
+		result := scorer.Score(c.X, c.Y)
+		darts[i] = &result
+		if c.Target != nil {
+			target := model.DartTarget{Multiplier: model.Multiplier(c.Target.Multiplier), Number: c.Target.Number}
+			if err := validateBed(target, gameState.Game.Simulator.GetBoardSpec()); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid target for dart %d: %v", i+1, err), http.StatusBadRequest)
+				return
+			}
+			records = append(records, model.ThrowRecord{Target: target, Result: result.DartTarget, Landing: &result.Landing})
+		}
+	}
+
+	result, err := gameState.Game.SubmitDarts(darts)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to submit darts: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Only darts that counted teach us about the player
+	for _, record := range records {
+		gameState.getEstimator(player).Add(record)
+		gameState.getHistory(player).Add(record)
+	}
+	if gameState.PlayerOuts {
+		gameState.refreshPlayerOuts(player)
+	}
+
+	resp := s.buildGameStateResponse(gameState, result)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// PlayerAccuracy handles GET and PUT /games/{id}/players/{player_id}/accuracy.
+// PUT replaces the player's accuracy profile with the given targets.
+func (s *Server) PlayerAccuracy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract game and player IDs from URL path
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/games/"), "/")
+	if len(parts) != 4 {
+		http.Error(w, "Invalid path", http.StatusBadRequest)
+		return
+	}
+
+	gameID, err := uuid.Parse(parts[0])
+	if err != nil {
+		http.Error(w, "Invalid game ID", http.StatusBadRequest)
+		return
+	}
+	playerID, err := uuid.Parse(parts[2])
+	if err != nil {
+		http.Error(w, "Invalid player ID", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	gameState, exists := s.games[gameID]
+	s.mu.Unlock()
+
+	if !exists {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+	gameState.mu.Lock()
+	defer gameState.mu.Unlock()
+
+	var player *oh1.Player
+	for _, p := range gameState.Game.Players {
+		if p.ID == playerID {
+			player = p
+		}
+	}
+	if player == nil {
+		http.Error(w, "Player not found", http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		var req AccuracyProfileData
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		accuracy := make(map[model.DartTarget]float64, len(req.Targets))
+		for _, t := range req.Targets {
+			target := model.DartTarget{Multiplier: model.Multiplier(t.Multiplier), Number: t.Number}
+			if err := validateBed(target, gameState.Game.Simulator.GetBoardSpec()); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid target: %v", err), http.StatusBadRequest)
+				return
+			}
+			if !(t.SpreadFactor > 0) || math.IsInf(t.SpreadFactor, 1) {
+				http.Error(w, fmt.Sprintf("spread_factor must be positive, got %v", t.SpreadFactor), http.StatusBadRequest)
+				return
+			}
+			accuracy[target] = t.SpreadFactor
+		}
+		player.TargetAccuracy = accuracy
+	}
+
+	resp := AccuracyProfileData{Targets: []TargetAccuracyData{}}
+	for target, factor := range player.TargetAccuracy {
+		resp.Targets = append(resp.Targets, TargetAccuracyData{
+			Multiplier:   int(target.Multiplier),
+			Number:       target.Number,
+			SpreadFactor: factor,
+		})
+	}
+	sort.Slice(resp.Targets, func(i, j int) bool {
+		a, b := resp.Targets[i], resp.Targets[j]
+		if a.Multiplier != b.Multiplier {
+			return a.Multiplier > b.Multiplier
+		}
+		return a.Number > b.Number
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// getEstimator returns the dispersion estimator for a player, creating it on first use
+func (gs *GameState) getEstimator(player *oh1.Player) *model.DispersionEstimator {
+	if gs.Estimators == nil {
+		gs.Estimators = make(map[uuid.UUID]*model.DispersionEstimator)
+	}
+	e, ok := gs.Estimators[player.ID]
+	if !ok {
+		prior := gs.Game.Simulator.GetCalibratedSpread(player.GetThreeDA(), nil, player.GetScoringPreference())
+		e = model.NewDispersionEstimator(gs.Game.Simulator.GetBoardSpec(), prior)
+		gs.Estimators[player.ID] = e
+	}
+	return e
+}
+
+// refreshPlayerOuts retailors a real player's checkouts to the spread and bias learned from their darts,
+// if any have been recorded since they were last tailored
+func (gs *GameState) refreshPlayerOuts(player *oh1.Player) {
+	e := gs.getEstimator(player)
+	if e.GetRecordCount() == 0 || e.GetRecordCount() == gs.tailoredTo[player.ID] {
+		return
+	}
+	if gs.tailoredTo == nil {
+		gs.tailoredTo = make(map[uuid.UUID]int)
+	}
+	gs.tailoredTo[player.ID] = e.GetRecordCount()
+	profile := player.PlayerProfile
+	profile.AimBias = e.GetBias()
+	player.Outs = oh1.NewPlayerOutChart(gs.Game.Simulator, &profile, e.GetSpread())
+}
+
+// getHistory returns the recorded darts of a player, creating the history on first use
+func (gs *GameState) getHistory(player *oh1.Player) *model.ThrowHistory {
+	if gs.Histories == nil {
+		gs.Histories = make(map[uuid.UUID]*model.ThrowHistory)
+	}
+	h, ok := gs.Histories[player.ID]
+	if !ok {
+		h = model.NewThrowHistory()
+		gs.Histories[player.ID] = h
+	}
+	return h
+}
+
+// PlayerGhost handles GET and POST /games/{id}/players/{player_id}/ghost.
+// GET reports the player's learned dispersion; POST also adds a simulated ghost of them to the game.
+func (s *Server) PlayerGhost(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract game and player IDs from URL path
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/games/"), "/")
+	if len(parts) != 4 {
+		http.Error(w, "Invalid path", http.StatusBadRequest)
+		return
+	}
+
+	gameID, err := uuid.Parse(parts[0])
+	if err != nil {
+		http.Error(w, "Invalid game ID", http.StatusBadRequest)
+		return
+	}
+	playerID, err := uuid.Parse(parts[2])
+	if err != nil {
+		http.Error(w, "Invalid player ID", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	gameState, exists := s.games[gameID]
+	s.mu.Unlock()
+
+	if !exists {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+	gameState.mu.Lock()
+	defer gameState.mu.Unlock()
+
+	var player *oh1.Player
+	for _, p := range gameState.Game.Players {
+		if p.ID == playerID {
+			player = p
+		}
+	}
+	if player == nil {
+		http.Error(w, "Player not found", http.StatusNotFound)
+		return
+	}
+
+	estimator := gameState.getEstimator(player)
+	low, high := estimator.GetSpreadInterval(0.9)
+	bias := estimator.GetBias()
+	resp := DispersionEstimateData{
+		PlayerID:   player.ID.String(),
+		Darts:      estimator.GetRecordCount(),
+		Spread:     estimator.GetSpread(),
+		SpreadLow:  low,
+		SpreadHigh: high,
+		BiasX:      bias.X,
+		BiasY:      bias.Y,
+	}
+
+	if r.Method == http.MethodPost {
+		var req GhostRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" {
+			req.Name = player.GetName() + " (ghost)"
+		}
+		pref := player.GetScoringPreference()
+		if req.ScoringPreference != "" {
+			var ok bool
+			if pref, ok = model.ScoringPreferenceByName(req.ScoringPreference); !ok {
+				http.Error(w, fmt.Sprintf("Unknown scoring preference %q", req.ScoringPreference), http.StatusBadRequest)
+				return
+			}
+		}
+
+		ghost := estimator.NewGhost(req.Name, pref)
+		ghost.PreferredDoubles = player.PreferredDoubles
+		ghost.RouteOverrides = player.RouteOverrides
+		switch req.Kind {
+		case "", "parametric":
+		case "replay":
+			history := gameState.getHistory(player)
+			if history.Len() == 0 {
+				http.Error(w, "No recorded darts to replay", http.StatusConflict)
+				return
+			}
+			ghost.ReplayHistory = history
+		default:
+			http.Error(w, fmt.Sprintf("Unknown ghost kind %q", req.Kind), http.StatusBadRequest)
+			return
+		}
+		ghostPlayer := gameState.Game.AddPlayer(ghost)
+		if gameState.PlayerOuts {
+			gameState.Game.UsePlayerOuts(ghostPlayer)
+		}
+		resp.GhostID = ghost.ID.String()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+const (
+	heatmapResolution    = 4.0 // default grid spacing (mm) of heatmaps drawn on the board
+	minHeatmapResolution = 2.0 // finest spacing a request may ask for; each halving quadruples the work
+)
+
+// BoardSVG handles GET /games/{id}/board.svg, drawing the game's board.
+// Query parameters:
+//   - darts: "turn" (default) marks the latest turn's darts, "leg" every dart of the leg
+//     with earlier turns faded, and "none" no darts
+//   - heatmap: a player ID, to overlay that player's expected score for every aim point
+//   - resolution: the heatmap's grid spacing in mm (default 4, at least 2)
+//   - size: width and height in pixels
+func (s *Server) BoardSVG(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract game ID from URL path
+	gameIDStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/games/"), "/board.svg")
+
+	gameID, err := uuid.Parse(gameIDStr)
+	if err != nil {
+		http.Error(w, "Invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.RLock()
+	gameState, exists := s.games[gameID]
+	s.mu.RUnlock()
+
+	if !exists {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+	gameState.mu.Lock()
+	defer gameState.mu.Unlock()
+
+	query := r.URL.Query()
+	opts := model.SVGOptions{}
+	if size := query.Get("size"); size != "" {
+		opts.Size, err = strconv.Atoi(size)
+		if err != nil || opts.Size <= 0 {
+			http.Error(w, fmt.Sprintf("Invalid size %q", size), http.StatusBadRequest)
+			return
+		}
+	}
+
+	resolution := heatmapResolution
+	if res := query.Get("resolution"); res != "" {
+		resolution, err = strconv.ParseFloat(res, 64)
+		if err != nil || !(resolution >= minHeatmapResolution) {
+			http.Error(w, fmt.Sprintf("Invalid resolution %q: must be at least %g mm", res, minHeatmapResolution), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var darts []oh1.DartThrown
+	switch query.Get("darts") {
+	case "", "turn":
+		darts = gameState.LegDarts[gameState.turnStart:]
+	case "leg":
+		darts = gameState.LegDarts
+	case "none":
+	default:
+		http.Error(w, fmt.Sprintf("Unknown darts %q", query.Get("darts")), http.StatusBadRequest)
+		return
+	}
+	latest := len(gameState.LegDarts) - gameState.turnStart
+	for i, d := range darts {
+		opts.Darts = append(opts.Darts, model.SVGDart{
+			Result: d.Result,
+			Label:  strconv.Itoa(d.Dart + 1),
+			Faded:  i < len(darts)-latest,
+		})
+	}
+
+	if id := query.Get("heatmap"); id != "" {
+		playerID, err := uuid.Parse(id)
+		if err != nil {
+			http.Error(w, "Invalid player ID", http.StatusBadRequest)
+			return
+		}
+		var player *oh1.Player
+		for _, p := range gameState.Game.Players {
+			if p.ID == playerID {
+				player = p
+			}
+		}
+		if player == nil {
+			http.Error(w, "Player not found", http.StatusNotFound)
+			return
+		}
+		opts.Heatmap, err = gameState.Game.Simulator.ExpectedScoreHeatmap(&player.PlayerProfile, player.GetSpread(), resolution)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid heatmap: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	gameState.Game.Simulator.WriteSVG(w, opts)
+}
+
 // GetGameState handles GET /games/{id}
 func (s *Server) GetGameState(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -338,15 +1152,17 @@ func (s *Server) GetGameState(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Game not found", http.StatusNotFound)
 		return
 	}
+	gameState.mu.Lock()
+	defer gameState.mu.Unlock()
 
-	resp := s.buildGameStateResponse(gameState, nil, false)
+	resp := s.buildGameStateResponse(gameState, nil)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
 // buildGameStateResponse constructs a GameStateResponse from the current game state
-func (s *Server) buildGameStateResponse(gameState *GameState, lastResult *model.TurnResult, gameOver bool) GameStateResponse {
+func (s *Server) buildGameStateResponse(gameState *GameState, lastResult *oh1.TurnResult) GameStateResponse {
 	players := make([]PlayerState, len(gameState.Game.Players))
 	for i, p := range gameState.Game.Players {
 		avgScore := 0.0
@@ -355,22 +1171,34 @@ func (s *Server) buildGameStateResponse(gameState *GameState, lastResult *model.
 		}
 
 		players[i] = PlayerState{
-			PlayerID:     p.Profile.ID.String(),
-			Name:         p.Profile.GetName(),
+			PlayerID:     p.ID.String(),
+			Name:         p.GetName(),
 			CurrentScore: p.CurrentScore,
-			IsSimulated:  true, // TODO: track this properly
-			ThreeDA:      p.Profile.GetThreeDA(),
+			IsSimulated:  p.GetType() == model.SimulatedPlayer,
+			ThreeDA:      p.GetThreeDA(),
 			Turns:        p.Turns,
 			TotalPoints:  p.TotalPoints,
 			AverageScore: avgScore,
 		}
+		outs := gameState.Game.GetOuts(p)
+		if out := outs.GetOut(p.CurrentScore); out != nil {
+			players[i].Checkout = notation.FormatTurn(out.Targets)
+			players[i].CheckoutOdds = out.Probability
+		} else if setup := outs.GetSetupToFinish(p.CurrentScore, p.GetScoringPreference()); setup != nil {
+			players[i].Setup = notation.FormatTurn(setup.Targets)
+			players[i].SetupLeaves = setup.Leave
+		}
 	}
 
 	resp := GameStateResponse{
 		GameID:   gameState.Game.ID.String(),
+		Seed:     gameState.Game.Seed,
 		Turn:     gameState.Game.Turn,
 		Players:  players,
-		GameOver: gameOver,
+		GameOver: gameState.Game.Finished,
+	}
+	if winner := gameState.Game.Winner; winner != nil {
+		resp.Winner = winner.GetName()
 	}
 
 	if len(players) > 0 {
@@ -382,11 +1210,10 @@ func (s *Server) buildGameStateResponse(gameState *GameState, lastResult *model.
 			PlayerName:     lastResult.PlayerName,
 			TotalScore:     lastResult.TotalScore,
 			RemainingScore: lastResult.RemainingScore,
-			ThreeDA:        lastResult.ThreeDA,
+			ThreeDA:        lastResult.CurrentThreeDA,
 		}
-
-		if gameOver {
-			resp.Winner = lastResult.PlayerName
+		for _, d := range lastResult.Results {
+			resp.LastTurnResult.Darts = append(resp.LastTurnResult.Darts, newDartData(d))
 		}
 	}
 
@@ -400,11 +1227,19 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 		path := r.URL.Path
 
 		// Route to appropriate handler based on path
-		if path[len(path)-8:] == "/players" {
+		if strings.HasSuffix(path, "/players") {
 			s.AddPlayer(w, r)
-		} else if len(path) > 16 && path[len(path)-16:] == "/turns/simulate" {
+		} else if strings.HasSuffix(path, "/accuracy") {
+			s.PlayerAccuracy(w, r)
+		} else if strings.HasSuffix(path, "/ghost") {
+			s.PlayerGhost(w, r)
+		} else if strings.HasSuffix(path, "/turns/simulate") {
 			s.PlaySimulatedTurn(w, r)
-		} else if len(path) > 13 && path[len(path)-13:] == "/turns/submit" {
+		} else if strings.HasSuffix(path, "/turns/coordinates") {
+			s.SubmitCoordinates(w, r)
+		} else if strings.HasSuffix(path, "/board.svg") {
+			s.BoardSVG(w, r)
+		} else if strings.HasSuffix(path, "/turns/submit") {
 			s.SubmitScore(w, r)
 		} else {
 			// Just game ID - get state
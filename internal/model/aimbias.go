@@ -0,0 +1,42 @@
+package model
+
+// AimBias is a player's systematic drift away from where they aim, in mm.
+// X is horizontal (positive to the right) and Y is vertical (positive up),
+// so a player who always drifts low-left of T20 towards the 5 has negative X and Y.
+// The bias moves the aim point before dispersion is sampled.
+type AimBias struct {
+	X       float64
+	Y       float64
+	Regions []RegionBias // optional overrides for parts of the board, first match wins
+}
+
+// RegionBias overrides the default bias for targets in a board region.
+// A zero Multiplier (Miss) matches any multiplier and a zero Number matches any number,
+// so {Multiplier: Double} covers every double and {Number: 20} covers the whole 20 bed.
+type RegionBias struct {
+	Multiplier Multiplier
+	Number     int
+	X          float64
+	Y          float64
+}
+
+// matches reports whether the region covers the target
+func (r RegionBias) matches(target DartTarget) bool {
+	if r.Multiplier != Miss && r.Multiplier != target.Multiplier {
+		return false
+	}
+	if r.Number != 0 && r.Number != target.Number {
+		return false
+	}
+	return true
+}
+
+// Offset returns the bias (in mm) to apply when aiming at target
+func (b *AimBias) Offset(target DartTarget) (float64, float64) {
+	for _, r := range b.Regions {
+		if r.matches(target) {
+			return r.X, r.Y
+		}
+	}
+	return b.X, b.Y
+}
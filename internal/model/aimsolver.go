@@ -0,0 +1,119 @@
+package model
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	aimSolverSamples     = 600 // dispersion samples averaged for each candidate aim point
+	aimSolverGridSize    = 40  // candidate points across the search window on each axis
+	aimSolverRefineSize  = 10  // candidate points on each axis in a refinement round
+	aimSolverRefinements = 3   // rounds of finer search around the best point
+)
+
+// AimObjective values the outcome of a single dart; the solver maximises its expectation
+type AimObjective func(result DartResult) float64
+
+// ExpectedPointsObjective values a dart by the points it scores
+func ExpectedPointsObjective() AimObjective {
+	return func(result DartResult) float64 {
+		return float64(result.Score)
+	}
+}
+
+// HitTargetObjective values a dart by whether it lands in the given bed
+func HitTargetObjective(target DartTarget) AimObjective {
+	return func(result DartResult) float64 {
+		if result.DartTarget == target {
+			return 1
+		}
+		return 0
+	}
+}
+
+// AimPoint is a Cartesian aim point in mm (x right, y up) and the objective's expected value there
+type AimPoint struct {
+	X     float64
+	Y     float64
+	Value float64
+}
+
+// SolveAim finds the aim point anywhere on the board that maximises the expected objective
+// for a player throwing with spread. The player's throw model and aim bias are applied.
+// The simulator's own random sequence is left untouched.
+func (s *Simulator) SolveAim(player *PlayerProfile, spread float64, objective AimObjective) AimPoint {
+	return s.SolveAimNear(player, spread, objective, 0, 0, s.spec.BoardRadius)
+}
+
+// SolveAimNear is SolveAim restricted to the square window of the given half-width around (x, y).
+// Restricting the search is much faster when the answer is known to be close to a bed,
+// e.g. when maximising the chance of hitting a particular double.
+func (s *Simulator) SolveAimNear(player *PlayerProfile, spread float64, objective AimObjective, x, y, halfWidth float64) AimPoint {
+	rng := rand.New(rand.NewSource(s.seed))
+	model := player.GetThrowModel()
+
+	// Common random numbers: every candidate is judged against the same misses
+	offsets := make([][2]float64, aimSolverSamples)
+	for i := range offsets {
+		dx, dy := model.Sample(rng, spread)
+		offsets[i] = [2]float64{dx, dy}
+	}
+
+	evaluate := func(aimX, aimY float64) float64 {
+		biasX, biasY := player.AimBias.Offset(s.GetTargetAt(aimX, aimY))
+		total := 0.0
+		for _, o := range offsets {
+			px, py := aimX+biasX+o[0], aimY+biasY+o[1]
+			total += objective(s.determineHit(math.Sqrt(px*px+py*py), math.Atan2(px, py)))
+		}
+		return total / float64(len(offsets))
+	}
+
+	best := AimPoint{X: x, Y: y, Value: evaluate(x, y)}
+	half := aimSolverGridSize / 2
+	step := halfWidth / float64(half)
+	for round := 0; round <= aimSolverRefinements; round++ {
+		cx, cy := best.X, best.Y
+		for i := -half; i <= half; i++ {
+			for j := -half; j <= half; j++ {
+				ax := cx + float64(i)*step
+				ay := cy + float64(j)*step
+				if v := evaluate(ax, ay); v > best.Value {
+					best = AimPoint{X: ax, Y: ay, Value: v}
+				}
+			}
+		}
+		// Zoom in on a window two coarse steps either side of the best point
+		half = aimSolverRefineSize / 2
+		step = 2 * step / float64(half)
+	}
+	return best
+}
+
+// GetTargetPoint returns the centre-of-bed aim point for a target
+func (s *Simulator) GetTargetPoint(target DartTarget) AimPoint {
+	x, y := s.getTargetPoint(target)
+	return AimPoint{X: x, Y: y}
+}
+
+// HitProbabilities estimates the chance of each bed when a player throwing with spread aims
+// at the centre of target, from the given number of simulated darts. Misses are keyed by a
+// target with the Miss multiplier. The player's throw model, aim bias and accuracy at the
+// target are applied; wires and earlier darts are not. The simulator's own random sequence
+// is left untouched, and every target is judged against the same misses.
+func (s *Simulator) HitProbabilities(player *PlayerProfile, target DartTarget, spread float64, samples int) map[DartTarget]float64 {
+	rng := rand.New(rand.NewSource(s.seed))
+	model := player.GetThrowModel()
+	spread = player.GetTargetSpread(target, spread)
+	aimX, aimY := s.getTargetPoint(target)
+	biasX, biasY := player.AimBias.Offset(target)
+
+	probabilities := make(map[DartTarget]float64)
+	for range samples {
+		dx, dy := model.Sample(rng, spread)
+		px, py := aimX+biasX+dx, aimY+biasY+dy
+		probabilities[s.determineHit(math.Sqrt(px*px+py*py), math.Atan2(px, py)).DartTarget] += 1.0 / float64(samples)
+	}
+	return probabilities
+}
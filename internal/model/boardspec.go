@@ -0,0 +1,124 @@
+package model
+
+// Ring is a scoring ring outside the bull, such as the treble or double ring
+type Ring struct {
+	Multiplier Multiplier
+	Inner      float64 // inner edge radius in mm
+	Outer      float64 // outer edge radius in mm
+}
+
+// BoardSpec describes the geometry of a dartboard in mm.
+// Anything inside the bull radii or outside every ring (but within BoardRadius) scores a single.
+type BoardSpec struct {
+	Name             string
+	DoubleBullRadius float64 // inner bull
+	SingleBullRadius float64 // outer bull
+	Rings            []Ring  // ordered from the centre outwards
+	BoardRadius      float64 // outer edge of the scoring area
+}
+
+// SteelTipBoardSpec returns the standard steel-tip board dimensions
+func SteelTipBoardSpec() *BoardSpec {
+	return &BoardSpec{
+		Name:             "steel",
+		DoubleBullRadius: DoubleBullRadius,
+		SingleBullRadius: SingleBullRadius,
+		Rings: []Ring{
+			{Multiplier: Triple, Inner: TripleInnerRadius, Outer: TripleOuterRadius},
+			{Multiplier: Double, Inner: DoubleInnerRadius, Outer: DoubleOuterRadius},
+		},
+		BoardRadius: BoardRadius,
+	}
+}
+
+// SoftTipBoardSpec returns the dimensions of a 15.5" soft-tip electronic board,
+// which has a larger scoring area and wider beds than a steel-tip board
+func SoftTipBoardSpec() *BoardSpec {
+	return &BoardSpec{
+		Name:             "soft",
+		DoubleBullRadius: 8.0,
+		SingleBullRadius: 20.0,
+		Rings: []Ring{
+			{Multiplier: Triple, Inner: 107.0, Outer: 117.0},
+			{Multiplier: Double, Inner: 185.0, Outer: 197.0},
+		},
+		BoardRadius: 197.0,
+	}
+}
+
+// QuadroBoardSpec returns a quadro board: a steel-tip board with an extra
+// quadruple ring between the treble and double rings
+func QuadroBoardSpec() *BoardSpec {
+	return &BoardSpec{
+		Name:             "quadro",
+		DoubleBullRadius: DoubleBullRadius,
+		SingleBullRadius: SingleBullRadius,
+		Rings: []Ring{
+			{Multiplier: Triple, Inner: TripleInnerRadius, Outer: TripleOuterRadius},
+			{Multiplier: Quadruple, Inner: 130.0, Outer: 138.0},
+			{Multiplier: Double, Inner: DoubleInnerRadius, Outer: DoubleOuterRadius},
+		},
+		BoardRadius: BoardRadius,
+	}
+}
+
+// BoardSpecByName returns the preset with the given name ("steel", "soft" or "quadro")
+func BoardSpecByName(name string) (*BoardSpec, bool) {
+	switch name {
+	case "", "steel":
+		return SteelTipBoardSpec(), true
+	case "soft":
+		return SoftTipBoardSpec(), true
+	case "quadro":
+		return QuadroBoardSpec(), true
+	default:
+		return nil, false
+	}
+}
+
+// GetRing returns the ring that scores the given multiplier
+func (b *BoardSpec) GetRing(multiplier Multiplier) (Ring, bool) {
+	for _, r := range b.Rings {
+		if r.Multiplier == multiplier {
+			return r, true
+		}
+	}
+	return Ring{}, false
+}
+
+// GetMultiplierAt returns the multiplier scored at a radius outside the bull
+func (b *BoardSpec) GetMultiplierAt(radius float64) Multiplier {
+	if radius > b.BoardRadius {
+		return Miss
+	}
+	for _, r := range b.Rings {
+		if radius >= r.Inner && radius <= r.Outer {
+			return r.Multiplier
+		}
+	}
+	return Single
+}
+
+// GetWireRadii returns the radius of every circular wire on the board
+func (b *BoardSpec) GetWireRadii() []float64 {
+	radii := []float64{b.DoubleBullRadius, b.SingleBullRadius}
+	for _, r := range b.Rings {
+		radii = append(radii, r.Inner, r.Outer)
+	}
+	return radii
+}
+
+// GetSingleRadius returns the middle of the largest single bed by area, the natural place to aim for a single
+func (b *BoardSpec) GetSingleRadius() float64 {
+	bestInner, bestOuter := b.SingleBullRadius, b.BoardRadius
+	inner := b.SingleBullRadius
+	best := -1.0
+	for _, r := range append(b.Rings, Ring{Inner: b.BoardRadius, Outer: b.BoardRadius}) {
+		if area := r.Inner*r.Inner - inner*inner; area > best {
+			best = area
+			bestInner, bestOuter = inner, r.Inner
+		}
+		inner = r.Outer
+	}
+	return (bestInner + bestOuter) / 2.0
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	calibrationMinSpread = 1.0   // mm
+	calibrationMaxSpread = 120.0 // mm
+	calibrationPoints    = 48
+	calibrationTurns     = 10000 // simulated turns per spread
+	calibrationSeed      = 1
+)
+
+// calibrationPoint is one entry of a calibration table
+type calibrationPoint struct {
+	spread  float64
+	threeDA float64
+}
+
+// Calibration maps a target three dart average to the spread that produces it,
+// for a particular board, throw model and scoring target.
+type Calibration struct {
+	points []calibrationPoint // sorted by increasing spread, with non-increasing 3DA
+}
+
+var (
+	calibrationMu    sync.Mutex
+	calibrationCache = make(map[string]*calibrationEntry)
+)
+
+// calibrationEntry builds one cached calibration exactly once, outside calibrationMu
+type calibrationEntry struct {
+	once sync.Once
+	c    *Calibration
+}
+
+// Calibrate returns the calibration for the board, throw model and scoring target,
+// simulating it on first use and caching the lookup table afterwards.
+// Calibration ignores wires and aim bias: it measures the throw model alone.
+// Throw models other than this package's are calibrated afresh on every call.
+func Calibrate(spec *BoardSpec, model ThrowModel, target DartTarget) *Calibration {
+	if model == nil {
+		model = GaussianThrowModel{}
+	}
+	params, ok := throwModelKey(model)
+	if !ok {
+		return buildCalibration(spec, model, target)
+	}
+	key := fmt.Sprintf("%+v|%s|%s", *spec, params, target.String())
+
+	calibrationMu.Lock()
+	entry, ok := calibrationCache[key]
+	if !ok {
+		entry = &calibrationEntry{}
+		calibrationCache[key] = entry
+	}
+	calibrationMu.Unlock()
+
+	entry.once.Do(func() {
+		entry.c = buildCalibration(spec, model, target)
+	})
+	return entry.c
+}
+
+// throwModelKey describes a throw model by its parameters, so equal models share a
+// calibration however they were built. It returns false for models it does not know.
+func throwModelKey(model ThrowModel) (string, bool) {
+	switch m := model.(type) {
+	case GaussianThrowModel:
+		return "gaussian", true
+	case *BivariateGaussianThrowModel:
+		return fmt.Sprintf("bivariate(%g,%g,%g)", m.HorizontalScale, m.VerticalScale, m.Correlation), true
+	case *StudentTThrowModel:
+		return fmt.Sprintf("student_t(%g)", m.DegreesOfFreedom), true
+	case *MixtureThrowModel:
+		key := "mixture("
+		for _, c := range m.Components {
+			sub, ok := throwModelKey(c.Model)
+			if !ok {
+				return "", false
+			}
+			key += fmt.Sprintf("%g*%g:%s;", c.Weight, c.Scale, sub)
+		}
+		return key + ")", true
+	}
+	return "", false
+}
+
+// buildCalibration simulates scoring turns over a log-spaced range of spreads
+func buildCalibration(spec *BoardSpec, model ThrowModel, target DartTarget) *Calibration {
+	sim := NewBoardSimulator(spec, calibrationSeed)
+	ratio := math.Pow(calibrationMaxSpread/calibrationMinSpread, 1.0/float64(calibrationPoints-1))
+
+	c := &Calibration{points: make([]calibrationPoint, calibrationPoints)}
+	spread := calibrationMinSpread
+	for i := range c.points {
+		// Replaying the same random sequence at every spread keeps the curve smooth
+		sim.Reset()
+		total := 0
+		for range calibrationTurns * 3 {
+			total += sim.Throw(target, spread, model).Score
+		}
+		threeDA := float64(total) / float64(calibrationTurns)
+
+		// Wider spread should never score more; flatten any simulation noise
+		if i > 0 && threeDA > c.points[i-1].threeDA {
+			threeDA = c.points[i-1].threeDA
+		}
+		c.points[i] = calibrationPoint{spread: spread, threeDA: threeDA}
+		spread *= ratio
+	}
+	return c
+}
+
+// GetSpread returns the spread (mm) that produces the given three dart average.
+// Averages outside the calibrated range are clamped to the nearest end of the table.
+func (c *Calibration) GetSpread(threeDA float64) float64 {
+	points := c.points
+	// first point whose 3DA is at or below the target
+	i := sort.Search(len(points), func(i int) bool {
+		return points[i].threeDA <= threeDA
+	})
+	if i == 0 {
+		return points[0].spread
+	}
+	if i == len(points) {
+		return points[len(points)-1].spread
+	}
+
+	hi, lo := points[i-1], points[i]
+	if hi.threeDA == lo.threeDA {
+		return lo.spread
+	}
+	// interpolate in log-spread, which is close to linear in 3DA
+	t := (hi.threeDA - threeDA) / (hi.threeDA - lo.threeDA)
+	return math.Exp(math.Log(hi.spread) + t*(math.Log(lo.spread)-math.Log(hi.spread)))
+}
+
+// GetThreeDA returns the three dart average expected at the given spread
+func (c *Calibration) GetThreeDA(spread float64) float64 {
+	points := c.points
+	i := sort.Search(len(points), func(i int) bool {
+		return points[i].spread >= spread
+	})
+	if i == 0 {
+		return points[0].threeDA
+	}
+	if i == len(points) {
+		return points[len(points)-1].threeDA
+	}
+
+	lo, hi := points[i-1], points[i]
+	t := (math.Log(spread) - math.Log(lo.spread)) / (math.Log(hi.spread) - math.Log(lo.spread))
+	return lo.threeDA + t*(hi.threeDA-lo.threeDA)
+}
+
+// GetCalibratedSpread returns the spread at which a player with the given throw model
+// and scoring preference averages threeDA on this simulator's board
+func (s *Simulator) GetCalibratedSpread(threeDA float64, model ThrowModel, preference ScoringPreference) float64 {
+	return Calibrate(s.spec, model, preference.GetTarget()).GetSpread(threeDA)
+}
@@ -0,0 +1,16 @@
+package model
+
+import "testing"
+
+func TestCalibrateSharesEqualModels(t *testing.T) {
+	spec := SteelTipBoardSpec()
+	target := DartTarget{Multiplier: Triple, Number: Twenty}
+	a := Calibrate(spec, NewGoodBadThrowModel(0.1, 2), target)
+	b := Calibrate(spec, NewGoodBadThrowModel(0.1, 2), target)
+	if a != b {
+		t.Error("equal mixture models built separately did not share a calibration")
+	}
+	if c := Calibrate(spec, NewGoodBadThrowModel(0.2, 2), target); c == a {
+		t.Error("mixture models with different parameters shared a calibration")
+	}
+}
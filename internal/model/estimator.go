@@ -0,0 +1,193 @@
+package model
+
+import "math"
+
+const (
+	estimatorMinSpread  = 2.0   // mm
+	estimatorMaxSpread  = 150.0 // mm
+	estimatorGridPoints = 100
+	estimatorBedSamples = 4000 // simulated darts per spread when tabulating bed outcomes
+	estimatorBedFloor   = 1e-4 // smallest probability assigned to any bed outcome
+	estimatorPriorLogSD = 0.5  // width of the log-normal prior on spread
+	estimatorPriorBias  = 10.0 // prior standard deviation (mm) of the bias on each axis
+)
+
+// ThrowRecord is a real dart: what the player aimed at and what they hit.
+// Landing is optional; darts with a landing point are far more informative.
+type ThrowRecord struct {
+	Target  DartTarget
+	Result  DartTarget
+	Landing *BoardPoint
+}
+
+// DispersionEstimator learns a player's spread and aim bias from their recorded darts.
+// The spread posterior is held on a log-spaced grid; darts with landing points contribute
+// the Gaussian likelihood of their miss, and darts with only a bed contribute the simulated
+// probability of hitting that bed. The bias is estimated from darts with landing points
+// as a Normal posterior shrunk towards zero.
+type DispersionEstimator struct {
+	sim      *Simulator
+	spreads  []float64
+	logPrior []float64
+	logBeds  []float64 // accumulated log-likelihood of bed-only records
+
+	// sufficient statistics of the misses of records with landing points
+	n     int
+	sumX  float64
+	sumY  float64
+	sumSq float64
+
+	bedTables map[DartTarget][]map[DartTarget]float64 // per target, per spread: outcome probabilities
+	records   int
+}
+
+// NewDispersionEstimator creates an estimator for darts thrown at a board with the given spec.
+// priorSpread (mm) centres the prior on the spread; zero gives a flat prior.
+func NewDispersionEstimator(spec *BoardSpec, priorSpread float64) *DispersionEstimator {
+	e := &DispersionEstimator{
+		sim:       NewBoardSimulator(spec, 1),
+		spreads:   make([]float64, estimatorGridPoints),
+		logPrior:  make([]float64, estimatorGridPoints),
+		logBeds:   make([]float64, estimatorGridPoints),
+		bedTables: make(map[DartTarget][]map[DartTarget]float64),
+	}
+	ratio := math.Pow(estimatorMaxSpread/estimatorMinSpread, 1.0/float64(estimatorGridPoints-1))
+	spread := estimatorMinSpread
+	for i := range e.spreads {
+		e.spreads[i] = spread
+		if priorSpread > 0 {
+			z := (math.Log(spread) - math.Log(priorSpread)) / estimatorPriorLogSD
+			e.logPrior[i] = -0.5 * z * z
+		}
+		spread *= ratio
+	}
+	return e
+}
+
+// Add updates the estimate with a recorded dart
+func (e *DispersionEstimator) Add(record ThrowRecord) {
+	e.records++
+	if record.Landing != nil {
+		aimX, aimY := e.sim.getTargetPoint(record.Target)
+		dx := record.Landing.X - aimX
+		dy := record.Landing.Y - aimY
+		e.n++
+		e.sumX += dx
+		e.sumY += dy
+		e.sumSq += dx*dx + dy*dy
+		return
+	}
+
+	table := e.getBedTable(record.Target)
+	for i := range e.spreads {
+		e.logBeds[i] += math.Log(math.Max(table[i][record.Result], estimatorBedFloor))
+	}
+}
+
+// getBedTable returns, for each spread on the grid, the probability of each bed when aiming at target
+func (e *DispersionEstimator) getBedTable(target DartTarget) []map[DartTarget]float64 {
+	if table, ok := e.bedTables[target]; ok {
+		return table
+	}
+	table := make([]map[DartTarget]float64, len(e.spreads))
+	for i, spread := range e.spreads {
+		e.sim.Reset()
+		counts := make(map[DartTarget]float64)
+		for range estimatorBedSamples {
+			counts[e.sim.Throw(target, spread, GaussianThrowModel{}).DartTarget]++
+		}
+		for bed := range counts {
+			counts[bed] /= estimatorBedSamples
+		}
+		table[i] = counts
+	}
+	e.bedTables[target] = table
+	return table
+}
+
+// posterior returns the normalised posterior weight of each spread on the grid
+func (e *DispersionEstimator) posterior() []float64 {
+	// Sum of squared misses about their mean; integrating out the bias leaves n-1 degrees of freedom per axis
+	scatter := 0.0
+	if e.n > 0 {
+		scatter = e.sumSq - (e.sumX*e.sumX+e.sumY*e.sumY)/float64(e.n)
+	}
+	dof := 2.0 * float64(max(e.n-1, 0))
+
+	logPost := make([]float64, len(e.spreads))
+	best := math.Inf(-1)
+	for i, spread := range e.spreads {
+		logPost[i] = e.logPrior[i] + e.logBeds[i] -
+			dof*math.Log(spread) - scatter/(2*spread*spread)
+		best = math.Max(best, logPost[i])
+	}
+
+	weights := make([]float64, len(e.spreads))
+	total := 0.0
+	for i := range logPost {
+		weights[i] = math.Exp(logPost[i] - best)
+		total += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= total
+	}
+	return weights
+}
+
+// GetSpread returns the posterior mean spread in mm
+func (e *DispersionEstimator) GetSpread() float64 {
+	mean := 0.0
+	for i, w := range e.posterior() {
+		mean += w * e.spreads[i]
+	}
+	return mean
+}
+
+// GetSpreadInterval returns a central credible interval for the spread covering the given mass, e.g. 0.9
+func (e *DispersionEstimator) GetSpreadInterval(mass float64) (float64, float64) {
+	weights := e.posterior()
+	tail := (1 - mass) / 2
+	lo, hi := e.spreads[0], e.spreads[len(e.spreads)-1]
+	cumulative := 0.0
+	foundLo := false
+	for i, w := range weights {
+		cumulative += w
+		if !foundLo && cumulative >= tail {
+			lo = e.spreads[i]
+			foundLo = true
+		}
+		if cumulative >= 1-tail {
+			hi = e.spreads[i]
+			break
+		}
+	}
+	return lo, hi
+}
+
+// GetBias returns the posterior mean aim bias in mm, shrunk towards zero when there are few darts
+func (e *DispersionEstimator) GetBias() AimBias {
+	if e.n == 0 {
+		return AimBias{}
+	}
+	spread := e.GetSpread()
+	// Normal-Normal update with a zero-mean prior on each axis
+	precision := 1/(estimatorPriorBias*estimatorPriorBias) + float64(e.n)/(spread*spread)
+	scale := 1 / (spread * spread * precision)
+	return AimBias{X: e.sumX * scale, Y: e.sumY * scale}
+}
+
+// GetRecordCount returns how many darts the estimate is based on
+func (e *DispersionEstimator) GetRecordCount() int {
+	return e.records
+}
+
+// NewGhost creates a simulated player who throws like the estimate: the learned bias,
+// and the three dart average that the learned spread produces on this board
+func (e *DispersionEstimator) NewGhost(name string, preference ScoringPreference) *PlayerProfile {
+	spread := e.GetSpread()
+	threeDA := Calibrate(e.sim.spec, GaussianThrowModel{}, preference.GetTarget()).GetThreeDA(spread)
+	ghost := NewPlayer(name, threeDA, preference)
+	ghost.PlayerType = SimulatedPlayer
+	ghost.AimBias = e.GetBias()
+	return ghost
+}
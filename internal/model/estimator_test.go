@@ -0,0 +1,74 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+// throwRecords throws n seeded darts at target with the given spread and bias and records them,
+// with their landing points if landings is set
+func throwRecords(n int, target DartTarget, spread float64, bias AimBias, landings bool) []ThrowRecord {
+	sim := NewSeededSimulator(7)
+	x, y := sim.getTargetPoint(target)
+	records := make([]ThrowRecord, n)
+	for i := range records {
+		result := sim.ThrowPoint(x+bias.X, y+bias.Y, spread, GaussianThrowModel{})
+		records[i] = ThrowRecord{Target: target, Result: result.DartTarget}
+		if landings {
+			records[i].Landing = &result.Landing
+		}
+	}
+	return records
+}
+
+func TestEstimatorRecoversSpreadAndBias(t *testing.T) {
+	const spread = 15.0
+	bias := AimBias{X: 4, Y: -3}
+	e := NewDispersionEstimator(SteelTipBoardSpec(), 0)
+	for _, r := range throwRecords(1000, DartTarget{Multiplier: Triple, Number: Twenty}, spread, bias, true) {
+		e.Add(r)
+	}
+
+	if got := e.GetRecordCount(); got != 1000 {
+		t.Errorf("GetRecordCount = %d, want 1000", got)
+	}
+	if got := e.GetSpread(); math.Abs(got-spread) > 0.05*spread {
+		t.Errorf("GetSpread = %.2f, want %.2f ± 5%%", got, spread)
+	}
+	if low, high := e.GetSpreadInterval(0.9); low > spread || high < spread {
+		t.Errorf("90%% interval %.2f-%.2f does not contain %.2f", low, high, spread)
+	}
+	got := e.GetBias()
+	if math.Abs(got.X-bias.X) > 1.5 || math.Abs(got.Y-bias.Y) > 1.5 {
+		t.Errorf("GetBias = (%.2f, %.2f), want (%.0f, %.0f) ± 1.5mm", got.X, got.Y, bias.X, bias.Y)
+	}
+}
+
+func TestEstimatorRecoversSpreadFromBeds(t *testing.T) {
+	const spread = 20.0
+	e := NewDispersionEstimator(SteelTipBoardSpec(), 0)
+	for _, r := range throwRecords(1000, DartTarget{Multiplier: Triple, Number: Twenty}, spread, AimBias{}, false) {
+		e.Add(r)
+	}
+	if got := e.GetSpread(); math.Abs(got-spread) > 0.15*spread {
+		t.Errorf("GetSpread = %.2f, want %.2f ± 15%%", got, spread)
+	}
+	if bias := e.GetBias(); bias.X != 0 || bias.Y != 0 {
+		t.Errorf("GetBias = %+v without landing points, want none", bias)
+	}
+}
+
+func TestEstimatorShrinksBiasWithFewDarts(t *testing.T) {
+	bias := AimBias{X: 10}
+	few, many := NewDispersionEstimator(SteelTipBoardSpec(), 15), NewDispersionEstimator(SteelTipBoardSpec(), 15)
+	records := throwRecords(200, DartTarget{Multiplier: Triple, Number: Twenty}, 15, bias, true)
+	for _, r := range records[:3] {
+		few.Add(r)
+	}
+	for _, r := range records {
+		many.Add(r)
+	}
+	if f, m := few.GetBias().X, many.GetBias().X; f >= m {
+		t.Errorf("bias from 3 darts %.2f is not shrunk below the bias from 200 darts %.2f", f, m)
+	}
+}
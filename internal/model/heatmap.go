@@ -0,0 +1,132 @@
+package model
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"math/rand"
+)
+
+// heatmapSamples is the number of dispersion samples averaged for each aim point
+const heatmapSamples = 400
+
+// MinHeatmapResolution is the finest grid spacing (mm) a heatmap may use
+const MinHeatmapResolution = 0.5
+
+// Heatmap is the expected score of aiming at each point of a square grid over the board.
+// Values[row][col] is the aim point (MinX + col*Resolution, MaxY - row*Resolution),
+// so row 0 is the top of the board and 20 is straight up.
+type Heatmap struct {
+	Resolution float64 // mm between grid points
+	MinX       float64
+	MaxY       float64
+	Values     [][]float64
+}
+
+// ExpectedScoreHeatmap computes the expected score of aiming at every point of a grid
+// with the given resolution (mm) covering the board, for a player throwing with spread.
+// The player's throw model and aim bias are applied; wires and earlier darts are not.
+// The simulator's own random sequence is left untouched. Resolutions finer than
+// MinHeatmapResolution are rejected.
+func (s *Simulator) ExpectedScoreHeatmap(player *PlayerProfile, spread, resolution float64) (*Heatmap, error) {
+	if !(resolution >= MinHeatmapResolution) {
+		return nil, fmt.Errorf("heatmap resolution must be at least %g mm, got %g", MinHeatmapResolution, resolution)
+	}
+	rng := rand.New(rand.NewSource(s.seed))
+	model := player.GetThrowModel()
+
+	// Sample one set of misses and reuse it for every aim point, so neighbouring
+	// cells differ only because of the board and not because of sampling noise.
+	offsets := make([][2]float64, heatmapSamples)
+	for i := range offsets {
+		dx, dy := model.Sample(rng, spread)
+		offsets[i] = [2]float64{dx, dy}
+	}
+
+	size := int(math.Floor(2*s.spec.BoardRadius/resolution)) + 1
+	h := &Heatmap{
+		Resolution: resolution,
+		MinX:       -s.spec.BoardRadius,
+		MaxY:       s.spec.BoardRadius,
+		Values:     make([][]float64, size),
+	}
+	for row := range h.Values {
+		h.Values[row] = make([]float64, size)
+		for col := range h.Values[row] {
+			aimX, aimY := h.Point(row, col)
+			biasX, biasY := player.AimBias.Offset(s.GetTargetAt(aimX, aimY))
+			total := 0
+			for _, o := range offsets {
+				total += s.scoreAt(aimX+biasX+o[0], aimY+biasY+o[1])
+			}
+			h.Values[row][col] = float64(total) / float64(len(offsets))
+		}
+	}
+	return h, nil
+}
+
+// Point returns the aim coordinates (mm) of a grid cell
+func (h *Heatmap) Point(row, col int) (float64, float64) {
+	return h.MinX + float64(col)*h.Resolution, h.MaxY - float64(row)*h.Resolution
+}
+
+// Best returns the aim point with the highest expected score and that score
+func (h *Heatmap) Best() (float64, float64, float64) {
+	bestRow, bestCol, best := 0, 0, math.Inf(-1)
+	for row, values := range h.Values {
+		for col, v := range values {
+			if v > best {
+				bestRow, bestCol, best = row, col, v
+			}
+		}
+	}
+	x, y := h.Point(bestRow, bestCol)
+	return x, y, best
+}
+
+// WritePNG encodes the heatmap as a PNG, one pixel per grid point,
+// coloured from blue (lowest expected score) to red (highest)
+func (h *Heatmap) WritePNG(w io.Writer) error {
+	lo, hi := h.valueRange()
+
+	size := len(h.Values)
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for row, values := range h.Values {
+		for col, v := range values {
+			t := 0.0
+			if hi > lo {
+				t = (v - lo) / (hi - lo)
+			}
+			img.Set(col, row, heatColor(t))
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// valueRange returns the lowest and highest expected scores
+func (h *Heatmap) valueRange() (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, values := range h.Values {
+		for _, v := range values {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	return lo, hi
+}
+
+// heatColor maps t in [0, 1] onto a blue-cyan-green-yellow-red scale
+func heatColor(t float64) color.RGBA {
+	clamp := func(v float64) uint8 {
+		return uint8(math.Max(0, math.Min(1, v)) * 255)
+	}
+	return color.RGBA{
+		R: clamp(1.5 - math.Abs(4*t-3)),
+		G: clamp(1.5 - math.Abs(4*t-2)),
+		B: clamp(1.5 - math.Abs(4*t-1)),
+		A: 255,
+	}
+}
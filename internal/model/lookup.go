@@ -0,0 +1,117 @@
+package model
+
+import "math"
+
+const (
+	segmentCount = 20
+	segmentAngle = 2.0 * math.Pi / segmentCount
+	radialStep   = 0.25 // width in mm of a radial lookup bin
+)
+
+// radialBed is one bin of the radial lookup table
+type radialBed struct {
+	multiplier Multiplier
+	bull       bool // inside the bull, where multiplier is Double or Single
+	exact      bool // the whole bin lies in one bed; otherwise the radius must be checked against the spec
+}
+
+// boardTables holds the geometry precomputed from a BoardSpec, so scoring a dart
+// needs no map lookups, trigonometry beyond one Atan2, or allocation
+type boardTables struct {
+	segments     [segmentCount]int                     // number in each segment, clockwise from the top
+	targets      [Quadruple + 1][Twenty + 1][2]float64 // aim point of each multiplier and number
+	radial       []radialBed                           // bed by radius, in bins of radialStep
+	wireRadii    []float64
+	singleRadius float64
+}
+
+// newBoardTables precomputes the lookup tables for a board.
+// The spec must not be modified afterwards.
+func newBoardTables(spec *BoardSpec) *boardTables {
+	t := &boardTables{
+		wireRadii:    spec.GetWireRadii(),
+		singleRadius: spec.GetSingleRadius(),
+	}
+	copy(t.segments[:], Board)
+
+	for multiplier := range t.targets {
+		radius := t.targetRadius(spec, Multiplier(multiplier))
+		for number := range t.targets[multiplier] {
+			angle := segmentAngleOf(number)
+			t.targets[multiplier][number] = [2]float64{radius * math.Sin(angle), radius * math.Cos(angle)}
+		}
+	}
+
+	// A bin is exact unless a bed boundary falls inside it
+	boundaries := append([]float64{spec.DoubleBullRadius, spec.SingleBullRadius, spec.BoardRadius}, t.wireRadii...)
+	t.radial = make([]radialBed, int(spec.BoardRadius/radialStep)+1)
+	for i := range t.radial {
+		lo, hi := float64(i)*radialStep, float64(i+1)*radialStep
+		bed := radialBedAt(spec, lo)
+		bed.exact = true
+		for _, b := range boundaries {
+			if b >= lo && b < hi {
+				bed.exact = false
+				break
+			}
+		}
+		t.radial[i] = bed
+	}
+	return t
+}
+
+// segmentAngleOf returns the centre angle (radians clockwise from the top) of a number's segment.
+// Numbers not on the board, including the bull, point at the top.
+func segmentAngleOf(number int) float64 {
+	for i, n := range Board {
+		if n == number {
+			return float64(i) * segmentAngle
+		}
+	}
+	return 0.0
+}
+
+// targetRadius returns the radius to aim at for a multiplier
+func (t *boardTables) targetRadius(spec *BoardSpec, multiplier Multiplier) float64 {
+	if multiplier == Single {
+		// Aim at the larger single area
+		return t.singleRadius
+	}
+	if ring, ok := spec.GetRing(multiplier); ok {
+		return (ring.Inner + ring.Outer) / 2.0
+	}
+	// Default to the triple ring
+	if ring, ok := spec.GetRing(Triple); ok {
+		return (ring.Inner + ring.Outer) / 2.0
+	}
+	return t.singleRadius
+}
+
+// radialBedAt classifies a radius directly from the spec
+func radialBedAt(spec *BoardSpec, radius float64) radialBed {
+	switch {
+	case radius <= spec.DoubleBullRadius:
+		return radialBed{multiplier: Double, bull: true}
+	case radius <= spec.SingleBullRadius:
+		return radialBed{multiplier: Single, bull: true}
+	default:
+		return radialBed{multiplier: spec.GetMultiplierAt(radius)}
+	}
+}
+
+// bedAt returns the bed at a radius within the board
+func (t *boardTables) bedAt(spec *BoardSpec, radius float64) radialBed {
+	if i := int(radius / radialStep); i < len(t.radial) && t.radial[i].exact {
+		return t.radial[i]
+	}
+	return radialBedAt(spec, radius)
+}
+
+// numberAt returns the number of the segment at an angle in radians clockwise from the top
+func (t *boardTables) numberAt(angle float64) int {
+	index := int(math.Floor(angle/segmentAngle+0.5)) % segmentCount
+	if index < 0 {
+		index += segmentCount
+	}
+	return t.segments[index]
+}
@@ -0,0 +1,190 @@
+// Package notation parses and formats the shorthand darts players write on scoreboards:
+// T20 for a treble 20, D16 for a double 16, S5 for a single 5, SB or 25 for the
+// outer bull, DB, BULL or 50 for the bullseye, MISS for a dart outside the scoring area,
+// whole turns such as "T20 T20 D20", and turn totals such as "180".
+package notation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+)
+
+// maxDarts is the number of darts in a turn
+const maxDarts = 3
+
+// Format returns the shorthand for a bed: T20, D16, S5, SB, DB, Q20 or MISS
+func Format(t model.DartTarget) string {
+	if t.Number == model.Bullseye {
+		switch t.Multiplier {
+		case model.Single:
+			return "SB"
+		case model.Double:
+			return "DB"
+		}
+	}
+	switch t.Multiplier {
+	case model.Single:
+		return "S" + strconv.Itoa(t.Number)
+	case model.Double:
+		return "D" + strconv.Itoa(t.Number)
+	case model.Triple:
+		return "T" + strconv.Itoa(t.Number)
+	case model.Quadruple:
+		return "Q" + strconv.Itoa(t.Number)
+	default:
+		return "MISS"
+	}
+}
+
+// FormatTurn returns the shorthand for a sequence of darts separated by spaces, e.g. "T20 T20 D20"
+func FormatTurn(targets []model.DartTarget) string {
+	parts := make([]string, len(targets))
+	for i, t := range targets {
+		parts[i] = Format(t)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Parse reads a single dart. It is case-insensitive and accepts:
+//   - S, D, T or Q followed by a number from 1 to 20, e.g. T20, d16, S5
+//   - SB, OB, OUTER, S25 or 25 for the outer bull
+//   - DB, BULL, BULLSEYE, D25 or 50 for the bullseye
+//   - MISS, M, 0 or - for a dart that scored nothing
+func Parse(s string) (model.DartTarget, error) {
+	token := strings.ToUpper(strings.TrimSpace(s))
+	if token == "" {
+		return model.DartTarget{}, fmt.Errorf("empty dart")
+	}
+
+	switch token {
+	case "MISS", "M", "0", "-":
+		return model.DartTarget{Multiplier: model.Miss}, nil
+	case "SB", "OB", "OUTER", "25":
+		return model.DartTarget{Multiplier: model.Single, Number: model.Bullseye}, nil
+	case "DB", "BULL", "BULLSEYE", "50":
+		return model.DartTarget{Multiplier: model.Double, Number: model.Bullseye}, nil
+	}
+
+	multiplier := model.Single
+	digits := token
+	switch token[0] {
+	case 'S':
+		digits = token[1:]
+	case 'D':
+		multiplier, digits = model.Double, token[1:]
+	case 'T':
+		multiplier, digits = model.Triple, token[1:]
+	case 'Q':
+		multiplier, digits = model.Quadruple, token[1:]
+	default:
+		if token[0] < '0' || token[0] > '9' {
+			return model.DartTarget{}, fmt.Errorf("invalid dart %q: expected S, D, T or Q followed by a number, SB, DB or MISS", s)
+		}
+		if number, err := strconv.Atoi(token); err == nil && number >= 1 && number <= 20 {
+			// a bare number could be a single or a dart's score; only the bull's are unambiguous
+			return model.DartTarget{}, fmt.Errorf("invalid dart %q: write a single with S, e.g. S%d", s, number)
+		}
+	}
+	if digits == "" {
+		return model.DartTarget{}, fmt.Errorf("invalid dart %q: missing number after %q", s, token[:1])
+	}
+
+	if !isPlainNumber(digits) {
+		return model.DartTarget{}, fmt.Errorf("invalid dart %q: %q is not a number", s, digits)
+	}
+	number, err := strconv.Atoi(digits)
+	if err != nil {
+		return model.DartTarget{}, fmt.Errorf("invalid dart %q: %q is not a number", s, digits)
+	}
+	switch {
+	case number == model.Bullseye && (multiplier == model.Single || multiplier == model.Double):
+		return model.DartTarget{Multiplier: multiplier, Number: model.Bullseye}, nil
+	case number == model.Bullseye:
+		return model.DartTarget{}, fmt.Errorf("invalid dart %q: the bull only has single (SB) and double (DB) beds", s)
+	case number < 1 || number > 20:
+		if multiplier == model.Single && digits == token && number <= 60 {
+			return model.DartTarget{}, fmt.Errorf("invalid dart %q: only 25 and 50 can be written as a bare number; write doubles and trebles as D or T, e.g. T20", s)
+		}
+		return model.DartTarget{}, fmt.Errorf("invalid dart %q: the board is numbered 1 to 20", s)
+	}
+	return model.DartTarget{Multiplier: multiplier, Number: number}, nil
+}
+
+// isPlainNumber reports whether s is written with digits only and no leading zero, e.g. 20 but not +20 or 020
+func isPlainNumber(s string) bool {
+	if s == "" || (s[0] == '0' && len(s) > 1) {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTurn reads one to three darts separated by spaces or commas, e.g. "T20 T20 D20"
+func ParseTurn(s string) ([]model.DartTarget, error) {
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\t'
+	})
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty turn")
+	}
+	if len(tokens) > maxDarts {
+		return nil, fmt.Errorf("invalid turn %q: a turn has at most %d darts, got %d", s, maxDarts, len(tokens))
+	}
+	targets := make([]model.DartTarget, len(tokens))
+	for i, token := range tokens {
+		t, err := Parse(token)
+		if err != nil {
+			return nil, fmt.Errorf("dart %d: %w", i+1, err)
+		}
+		targets[i] = t
+	}
+	return targets, nil
+}
+
+// ParseTotal reads a turn total such as "180", rejecting totals that three darts
+// on a standard board cannot score, such as 179
+func ParseTotal(s string) (int, error) {
+	digits := strings.TrimSpace(s)
+	if !isPlainNumber(digits) {
+		return 0, fmt.Errorf("invalid total %q: not a number", s)
+	}
+	total, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid total %q: not a number", s)
+	}
+	if total < 0 || total > 180 {
+		return 0, fmt.Errorf("invalid total %d: a turn scores 0 to 180", total)
+	}
+	if !reachableTotals[total] {
+		return 0, fmt.Errorf("invalid total %d: three darts cannot score %d", total, total)
+	}
+	return total, nil
+}
+
+// reachableTotals marks the totals three darts can score on a standard board
+var reachableTotals = func() [181]bool {
+	var scores []int
+	for n := 1; n <= 20; n++ {
+		scores = append(scores, n, 2*n, 3*n)
+	}
+	scores = append(scores, 0, 25, 50)
+
+	var reachable [181]bool
+	for _, a := range scores {
+		for _, b := range scores {
+			for _, c := range scores {
+				if total := a + b + c; total <= 180 {
+					reachable[total] = true
+				}
+			}
+		}
+	}
+	return reachable
+}()
@@ -0,0 +1,105 @@
+package notation
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want model.DartTarget
+	}{
+		{"T20", model.DartTarget{Multiplier: model.Triple, Number: 20}},
+		{"d16", model.DartTarget{Multiplier: model.Double, Number: 16}},
+		{" S5 ", model.DartTarget{Multiplier: model.Single, Number: 5}},
+		{"Q20", model.DartTarget{Multiplier: model.Quadruple, Number: 20}},
+		{"SB", model.DartTarget{Multiplier: model.Single, Number: model.Bullseye}},
+		{"25", model.DartTarget{Multiplier: model.Single, Number: model.Bullseye}},
+		{"S25", model.DartTarget{Multiplier: model.Single, Number: model.Bullseye}},
+		{"DB", model.DartTarget{Multiplier: model.Double, Number: model.Bullseye}},
+		{"bull", model.DartTarget{Multiplier: model.Double, Number: model.Bullseye}},
+		{"50", model.DartTarget{Multiplier: model.Double, Number: model.Bullseye}},
+		{"D25", model.DartTarget{Multiplier: model.Double, Number: model.Bullseye}},
+		{"MISS", model.DartTarget{Multiplier: model.Miss}},
+		{"-", model.DartTarget{Multiplier: model.Miss}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, in := range []string{"", "T25", "S0", "D0", "21", "T21", "20", "5", "60", "X20", "T", "Tx", "T2O", "T+5", "T-5", "T05", "D020", "S 5", "05"} {
+		if got, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	targets := []model.DartTarget{
+		{Multiplier: model.Miss},
+		{Multiplier: model.Single, Number: model.Bullseye},
+		{Multiplier: model.Double, Number: model.Bullseye},
+	}
+	for _, m := range []model.Multiplier{model.Single, model.Double, model.Triple, model.Quadruple} {
+		for n := 1; n <= 20; n++ {
+			targets = append(targets, model.DartTarget{Multiplier: m, Number: n})
+		}
+	}
+	for _, target := range targets {
+		got, err := Parse(Format(target))
+		if err != nil {
+			t.Errorf("Parse(Format(%v)) = %v", target, err)
+		} else if got != target {
+			t.Errorf("Parse(Format(%v)) = %v", target, got)
+		}
+	}
+}
+
+func TestParseTurn(t *testing.T) {
+	got, err := ParseTurn("T20, t19  DB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []model.DartTarget{
+		{Multiplier: model.Triple, Number: 20},
+		{Multiplier: model.Triple, Number: 19},
+		{Multiplier: model.Double, Number: model.Bullseye},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseTurn = %v, want %v", got, want)
+	}
+	if s := FormatTurn(got); s != "T20 T19 DB" {
+		t.Errorf("FormatTurn = %q, want %q", s, "T20 T19 DB")
+	}
+
+	for _, in := range []string{"", " , ", "T20 T20 T20 T20", "T20 T25", "T20 20"} {
+		if got, err := ParseTurn(in); err == nil {
+			t.Errorf("ParseTurn(%q) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestParseTotal(t *testing.T) {
+	for _, in := range []string{"0", "180", "177", "171", " 60 ", "3"} {
+		if _, err := ParseTotal(in); err != nil {
+			t.Errorf("ParseTotal(%q): %v", in, err)
+		}
+	}
+	for _, in := range []string{"179", "178", "176", "175", "173", "172", "169", "181", "-1", "+60", "060", "T20", ""} {
+		if got, err := ParseTotal(in); err == nil {
+			t.Errorf("ParseTotal(%q) = %d, want an error", in, got)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package oh1
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+)
+
+// batchMaxTurns stops a leg that nobody can finish, e.g. between two very weak bots
+const batchMaxTurns = 500
+
+// z95 is the normal quantile for 95% confidence intervals
+const z95 = 1.959964
+
+// BatchConfig describes a Monte Carlo run of many independent legs or matches
+type BatchConfig struct {
+	Runs       int                    // number of legs, or of matches when LegsToWin > 1
+	LegsToWin  int                    // legs needed to win a match; 0 or 1 simulates single legs
+	StartScore int                    // defaults to 501
+	Players    []*model.PlayerProfile // simulated players, in throwing order for the first leg
+	Board      *model.BoardSpec       // defaults to steel-tip
+	Wires      model.WireConfig
+	Seed       int64 // every run is seeded from this, so a batch is reproducible regardless of scheduling
+	Workers    int   // defaults to the number of CPUs
+	PlayerOuts bool  // give each player checkouts tailored to their accuracy rather than the standard chart
+
+	// Progress, if set, is called after each run completes with the number done so far.
+	// Calls are serialised.
+	Progress func(done, total int)
+}
+
+// Estimate is a sample mean with a 95% confidence interval
+type Estimate struct {
+	Mean float64
+	Low  float64
+	High float64
+}
+
+// BatchPlayerStats aggregates one player's results over a batch
+type BatchPlayerStats struct {
+	Name            string
+	LegsWon         int
+	MatchesWon      int
+	WinProbability  Estimate    // of the leg, or of the match when playing matches
+	DartsPerLeg     Estimate    // darts needed in legs the player won
+	DartsHistogram  map[int]int // darts needed -> number of legs won in that many darts
+	ThreeDA         Estimate    // per-leg three dart average
+	CheckoutPercent Estimate    // share of darts at a finishing double that hit
+	OneEighties     int         // total 180s
+	OneEightiesLeg  Estimate    // 180s per leg
+	checkoutHits    int
+	checkoutTries   int
+	dartsWon        []float64
+	legAverages     []float64
+	legOneEighties  []float64
+}
+
+// BatchResult is the outcome of RunBatch
+type BatchResult struct {
+	Runs    int // runs completed, fewer than requested if cancelled
+	Legs    int // legs played across all runs
+	Players []*BatchPlayerStats
+}
+
+// legOutcome is what one player did in one leg
+type legOutcome struct {
+	won           bool
+	darts         int
+	points        int
+	oneEighties   int
+	checkoutHits  int
+	checkoutTries int
+}
+
+// batchOuts are the read-only checkout charts shared by every leg of a batch
+type batchOuts struct {
+	game     *OutChart
+	players  []*OutChart                        // each player's tailored chart, or nil for the game's
+	profiles map[*model.PlayerProfile]*OutChart // charts for profiles' checkout preferences
+}
+
+// newBatchOuts solves the batch's checkout charts once, up front
+func newBatchOuts(cfg BatchConfig) *batchOuts {
+	outs := &batchOuts{game: NewOutChart(), profiles: make(map[*model.PlayerProfile]*OutChart)}
+	for _, profile := range cfg.Players {
+		if profile.HasCheckoutPreferences() {
+			outs.profiles[profile] = NewProfileOutChart(profile)
+		}
+	}
+	if cfg.PlayerOuts {
+		sim := model.NewBoardSimulator(cfg.Board, cfg.Seed)
+		g := &Game{Simulator: sim, StartScore: cfg.StartScore, Outs: outs.game, profileOuts: outs.profiles}
+		for _, profile := range cfg.Players {
+			p := g.AddPlayer(profile)
+			g.UsePlayerOuts(p)
+			outs.players = append(outs.players, p.Outs)
+		}
+	}
+	return outs
+}
+
+// runOutcome is the outcome of a single leg or match
+type runOutcome struct {
+	legs   [][]legOutcome // per leg, per player
+	winner int            // index of the player who won the run
+}
+
+// RunBatch simulates cfg.Runs independent legs or matches on a pool of workers, each with
+// its own Simulator, and aggregates the results. If ctx is cancelled it stops early and
+// returns the statistics of the completed runs together with ctx.Err().
+func RunBatch(ctx context.Context, cfg BatchConfig) (*BatchResult, error) {
+	if len(cfg.Players) == 0 {
+		return nil, errors.New("cannot run a batch with no players")
+	}
+	for _, p := range cfg.Players {
+		if p.GetType() != model.SimulatedPlayer {
+			return nil, errors.New("batch players must all be simulated")
+		}
+	}
+	if cfg.StartScore == 0 {
+		cfg.StartScore = 501
+	}
+	if cfg.Board == nil {
+		cfg.Board = model.SteelTipBoardSpec()
+	}
+	if cfg.LegsToWin < 1 {
+		cfg.LegsToWin = 1
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	outs := newBatchOuts(cfg)
+	jobs := make(chan int)
+	type completed struct {
+		index   int
+		outcome runOutcome
+	}
+	done := make(chan completed)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sim := model.NewBoardSimulator(cfg.Board, cfg.Seed)
+			sim.SetWireConfig(cfg.Wires)
+			g := newBatchGame(cfg, sim, outs)
+			for index := range jobs {
+				sim.Reseed(runSeed(cfg.Seed, index))
+				done <- completed{index: index, outcome: playRun(cfg, g)}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range cfg.Runs {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	outcomes := make([]*runOutcome, cfg.Runs)
+	count := 0
+	for c := range done {
+		outcomes[c.index] = &c.outcome
+		count++
+		if cfg.Progress != nil {
+			cfg.Progress(count, cfg.Runs)
+		}
+	}
+
+	// Aggregate in run order so the statistics don't depend on scheduling
+	result := aggregateBatch(cfg, outcomes)
+	return result, ctx.Err()
+}
+
+// runSeed derives an independent seed for a run (SplitMix64)
+func runSeed(base int64, index int) int64 {
+	z := uint64(base) + uint64(index+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
+// newBatchGame creates a worker's unobserved game, adding the players once so their
+// calibrated spreads and solved aim points are reused by every leg the worker plays.
+// Aim points are solved on a simulator of their own, whose seed does not change between
+// runs, so they are the same whichever run solves them first.
+func newBatchGame(cfg BatchConfig, sim *model.Simulator, outs *batchOuts) *Game {
+	g := &Game{
+		Simulator:   sim,
+		StartScore:  cfg.StartScore,
+		Outs:        outs.game,
+		Seed:        sim.GetSeed(),
+		profileOuts: outs.profiles,
+		aimSim:      model.NewBoardSimulator(cfg.Board, cfg.Seed),
+	}
+	for i, profile := range cfg.Players {
+		p := g.AddPlayer(profile)
+		if outs.players != nil {
+			p.Outs = outs.players[i]
+		}
+	}
+	return g
+}
+
+// playRun plays one leg or match, rotating the throw between legs
+func playRun(cfg BatchConfig, g *Game) runOutcome {
+	var outcome runOutcome
+	wins := make([]int, len(cfg.Players))
+	for leg := 0; ; leg++ {
+		legResult, winner := playLeg(cfg, g, leg%len(cfg.Players))
+		outcome.legs = append(outcome.legs, legResult)
+		if winner < 0 {
+			// nobody finished; give up on the run rather than loop forever
+			outcome.winner = -1
+			return outcome
+		}
+		wins[winner]++
+		if wins[winner] >= cfg.LegsToWin {
+			outcome.winner = winner
+			return outcome
+		}
+	}
+}
+
+// playLeg plays a single unobserved leg with the given player throwing first.
+// It returns each player's outcome and the winner's index, or -1 if nobody finished.
+func playLeg(cfg BatchConfig, g *Game, first int) ([]legOutcome, int) {
+	g.resetLeg(first)
+
+	outcomes := make([]legOutcome, len(cfg.Players))
+	for range batchMaxTurns {
+		index := g.CurrentPlayer
+		p := g.Players[index]
+		before := p.CurrentScore
+		r, err := g.PlayTurn()
+		if err != nil {
+			// RunBatch only accepts simulated players and the leg was reset, so this is a bug
+			panic(err)
+		}
+
+		o := &outcomes[index]
+		if r.TotalScore == 180 {
+			o.oneEighties++
+		}
+		// Count darts thrown at a finishing double
+		remaining := before
+		for _, d := range r.Results {
+			if isDoubleFinish(remaining) {
+				o.checkoutTries++
+			}
+			remaining -= d.Score
+		}
+		if r.Type == WinTurn {
+			o.checkoutHits++
+			for i, pl := range g.Players {
+				outcomes[i].darts = pl.Throws
+				outcomes[i].points = pl.TotalPoints
+			}
+			o.won = true
+			return outcomes, index
+		}
+	}
+
+	for i, pl := range g.Players {
+		outcomes[i].darts = pl.Throws
+		outcomes[i].points = pl.TotalPoints
+	}
+	return outcomes, -1
+}
+
+// isDoubleFinish reports whether a score can be finished with a single double
+func isDoubleFinish(score int) bool {
+	return score == 50 || (score >= 2 && score <= 40 && score%2 == 0)
+}
+
+// aggregateBatch turns the completed runs into per-player statistics
+func aggregateBatch(cfg BatchConfig, outcomes []*runOutcome) *BatchResult {
+	result := &BatchResult{}
+	for _, profile := range cfg.Players {
+		result.Players = append(result.Players, &BatchPlayerStats{
+			Name:           profile.GetName(),
+			DartsHistogram: make(map[int]int),
+		})
+	}
+
+	for _, run := range outcomes {
+		if run == nil {
+			continue
+		}
+		result.Runs++
+		if run.winner >= 0 {
+			result.Players[run.winner].MatchesWon++
+		}
+		for _, leg := range run.legs {
+			result.Legs++
+			for i, o := range leg {
+				stats := result.Players[i]
+				if o.won {
+					stats.LegsWon++
+					stats.DartsHistogram[o.darts]++
+					stats.dartsWon = append(stats.dartsWon, float64(o.darts))
+				}
+				if o.darts > 0 {
+					stats.legAverages = append(stats.legAverages, float64(o.points)/float64(o.darts)*3.0)
+				}
+				stats.OneEighties += o.oneEighties
+				stats.legOneEighties = append(stats.legOneEighties, float64(o.oneEighties))
+				stats.checkoutHits += o.checkoutHits
+				stats.checkoutTries += o.checkoutTries
+			}
+		}
+	}
+
+	for _, stats := range result.Players {
+		if cfg.LegsToWin > 1 {
+			stats.WinProbability = proportionEstimate(stats.MatchesWon, result.Runs)
+		} else {
+			stats.WinProbability = proportionEstimate(stats.LegsWon, result.Legs)
+		}
+		stats.DartsPerLeg = meanEstimate(stats.dartsWon)
+		stats.ThreeDA = meanEstimate(stats.legAverages)
+		stats.CheckoutPercent = proportionEstimate(stats.checkoutHits, stats.checkoutTries)
+		stats.CheckoutPercent.Mean *= 100
+		stats.CheckoutPercent.Low *= 100
+		stats.CheckoutPercent.High *= 100
+		stats.OneEightiesLeg = meanEstimate(stats.legOneEighties)
+	}
+	return result
+}
+
+// meanEstimate returns the sample mean with a normal-approximation 95% interval
+func meanEstimate(samples []float64) Estimate {
+	n := float64(len(samples))
+	if n == 0 {
+		return Estimate{}
+	}
+	sum := 0.0
+	for _, v := range samples {
+		sum += v
+	}
+	mean := sum / n
+	if n < 2 {
+		return Estimate{Mean: mean, Low: mean, High: mean}
+	}
+	ss := 0.0
+	for _, v := range samples {
+		ss += (v - mean) * (v - mean)
+	}
+	half := z95 * math.Sqrt(ss/(n-1)/n)
+	return Estimate{Mean: mean, Low: mean - half, High: mean + half}
+}
+
+// proportionEstimate returns successes/trials with a Wilson score 95% interval
+func proportionEstimate(successes, trials int) Estimate {
+	if trials == 0 {
+		return Estimate{}
+	}
+	n := float64(trials)
+	p := float64(successes) / n
+	z2 := z95 * z95
+	centre := (p + z2/(2*n)) / (1 + z2/n)
+	half := z95 * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+	// In exact arithmetic the interval contains p and lies within [0, 1]; rounding can break both at the ends
+	low := math.Max(0, math.Min(centre-half, p))
+	high := math.Min(1, math.Max(centre+half, p))
+	return Estimate{Mean: p, Low: low, High: high}
+}
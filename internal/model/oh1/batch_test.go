@@ -0,0 +1,105 @@
+package oh1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+)
+
+// newBatchPlayers returns two simulated players with the given three dart averages
+func newBatchPlayers(a, b float64) []*model.PlayerProfile {
+	players := []*model.PlayerProfile{
+		model.NewPlayer("A", a, model.TwentiesScoringPreference),
+		model.NewPlayer("B", b, model.TwentiesScoringPreference),
+	}
+	for _, p := range players {
+		p.PlayerType = model.SimulatedPlayer
+	}
+	return players
+}
+
+func TestRunBatchIsReproducibleAcrossWorkers(t *testing.T) {
+	cfg := BatchConfig{Runs: 200, Players: newBatchPlayers(45, 60), Seed: 5}
+	var results []*BatchResult
+	for _, workers := range []int{1, 3, 8} {
+		cfg.Workers = workers
+		result, err := RunBatch(context.Background(), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+	for i, result := range results[1:] {
+		if !reflect.DeepEqual(result, results[0]) {
+			t.Errorf("batch with %d workers differs from one with 1", []int{3, 8}[i])
+		}
+	}
+
+	cfg.Seed = 6
+	other, err := RunBatch(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(other, results[0]) {
+		t.Error("seeds 5 and 6 gave identical batches")
+	}
+}
+
+func TestRunBatchCountsEveryWin(t *testing.T) {
+	for _, legsToWin := range []int{1, 3} {
+		result, err := RunBatch(context.Background(), BatchConfig{
+			Runs: 100, LegsToWin: legsToWin, Players: newBatchPlayers(45, 60), Seed: 1, Workers: 2,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Runs != 100 {
+			t.Errorf("%d legs to win: %d runs, want 100", legsToWin, result.Runs)
+		}
+		legs, matches := 0, 0
+		for _, p := range result.Players {
+			legs += p.LegsWon
+			matches += p.MatchesWon
+			if w := p.WinProbability; !(0 <= w.Low && w.Low <= w.Mean && w.Mean <= w.High && w.High <= 1) {
+				t.Errorf("%d legs to win: %s wins %+v", legsToWin, p.Name, w)
+			}
+		}
+		if legs != result.Legs {
+			t.Errorf("%d legs to win: legs won sum to %d, want %d", legsToWin, legs, result.Legs)
+		}
+		if matches != result.Runs {
+			t.Errorf("%d legs to win: matches won sum to %d, want %d", legsToWin, matches, result.Runs)
+		}
+		if legsToWin > 1 && result.Legs < legsToWin*result.Runs {
+			t.Errorf("%d legs to win: only %d legs in %d matches", legsToWin, result.Legs, result.Runs)
+		}
+	}
+}
+
+func TestProportionEstimate(t *testing.T) {
+	for _, tt := range []struct{ successes, trials int }{{0, 1}, {1, 1}, {0, 10}, {10, 10}, {3, 10}, {500, 1000}, {1, 10000}} {
+		e := proportionEstimate(tt.successes, tt.trials)
+		if want := float64(tt.successes) / float64(tt.trials); e.Mean != want {
+			t.Errorf("%d/%d: mean %v, want %v", tt.successes, tt.trials, e.Mean, want)
+		}
+		if !(0 <= e.Low && e.Low <= e.Mean && e.Mean <= e.High && e.High <= 1) {
+			t.Errorf("%d/%d: interval %v-%v around %v", tt.successes, tt.trials, e.Low, e.High, e.Mean)
+		}
+	}
+	if e := proportionEstimate(0, 0); e != (Estimate{}) {
+		t.Errorf("0/0: %+v, want none", e)
+	}
+}
+
+func BenchmarkRunBatch(b *testing.B) {
+	players := newBatchPlayers(60, 60)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := RunBatch(context.Background(), BatchConfig{Runs: 100, Players: players, Seed: 1}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N*100)/b.Elapsed().Seconds(), "legs/s")
+}
@@ -0,0 +1,289 @@
+package oh1
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+	"github.com/kregan77/dartbuddy/internal/model/notation"
+)
+
+// maxCheckoutDarts is the most darts a checkout can take: one turn
+const maxCheckoutDarts = 3
+
+// OutRule decides which darts may finish a leg
+type OutRule int
+
+const (
+	DoubleOut OutRule = iota // finish on a double or the bullseye
+	SingleOut                // finish on any bed
+	MasterOut                // finish on a double, treble or the bullseye
+)
+
+// Finishes reports whether a dart in the given bed may finish a leg
+func (r OutRule) Finishes(t model.DartTarget) bool {
+	switch r {
+	case SingleOut:
+		return t.Multiplier != model.Miss
+	case MasterOut:
+		return t.Multiplier == model.Double || t.Multiplier == model.Triple
+	default:
+		return t.Multiplier == model.Double
+	}
+}
+
+// CheckoutPolicy ranks the legal checkouts of a score. Cost returns a penalty for a route;
+// the route with the lowest cost is recommended.
+type CheckoutPolicy interface {
+	Cost(out Out) float64
+}
+
+// StandardCheckoutPolicy ranks checkouts the way printed charts do: as few darts as possible,
+// a favourite finishing double, trebles thrown before singles, and the 20 and 19
+// where a treble is needed. The bull is only used when nothing else finishes.
+type StandardCheckoutPolicy struct {
+	Doubles  []int   // finishing doubles from most to least preferred; unlisted doubles rank after them
+	BullCost float64 // extra cost of finishing on the bull when it is not in Doubles; above costPerDart it is worth another dart to avoid
+}
+
+// NewStandardCheckoutPolicy creates the policy behind the standard chart
+func NewStandardCheckoutPolicy() *StandardCheckoutPolicy {
+	return &StandardCheckoutPolicy{
+		Doubles:  []int{20, 16, 8, 18, 12, 10, 4, 14, 6, 2, 19, 17, 15, 13, 11, 9, 7, 5, 3, 1},
+		BullCost: 1200,
+	}
+}
+
+// NewProfileCheckoutPolicy creates the standard policy with a player's preferred doubles
+// ranked first, followed by the rest in the standard order
+func NewProfileCheckoutPolicy(profile *model.PlayerProfile) *StandardCheckoutPolicy {
+	policy := NewStandardCheckoutPolicy()
+	if len(profile.PreferredDoubles) == 0 {
+		return policy
+	}
+	doubles := slices.Clone(profile.PreferredDoubles)
+	for _, d := range policy.Doubles {
+		if !slices.Contains(doubles, d) {
+			doubles = append(doubles, d)
+		}
+	}
+	policy.Doubles = doubles
+	return policy
+}
+
+// Policy weights: fewer darts dominate, then the finishing double, then the setup darts
+const (
+	costPerDart        = 1000.0
+	costDoublePlace    = 10.0 // per place down the preferred doubles
+	costUnlistedDouble = 250.0
+	costNotDouble      = 250.0 // finishing on a single or treble under single or master out
+	costSetupTreble    = 8.0
+	costSetupDouble    = 100.0
+	costSetupBull      = 100.0
+	costTrebleNumber   = 2.0  // per place a setup treble's number is down setupNumbers
+	costSingleNumber   = 0.5  // per place a setup single's number is down setupNumbers
+	costTrebleLate     = 20.0 // a treble thrown after a single or double
+	firstDartWeight    = 2.0  // the first dart's number counts more, as a miss there costs the most
+)
+
+// setupNumbers orders the numbers from most to least comfortable to set up on
+var setupNumbers = []int{20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+
+// Cost returns the penalty of a route under the standard chart's preferences
+func (p *StandardCheckoutPolicy) Cost(out Out) float64 {
+	targets := out.Targets
+	cost := costPerDart * float64(len(targets))
+
+	finish := targets[len(targets)-1]
+	if finish.Multiplier != model.Double {
+		cost += costNotDouble
+	} else if i := slices.Index(p.Doubles, finish.Number); i >= 0 {
+		cost += costDoublePlace * float64(i)
+	} else if finish.Number == model.Bullseye {
+		cost += p.BullCost
+	} else {
+		cost += costUnlistedDouble
+	}
+
+	sawSingle := false
+	for i, t := range targets[:len(targets)-1] {
+		cost += setupCost(t, i == 0)
+		if t.Multiplier == model.Triple && sawSingle {
+			cost += costTrebleLate
+		}
+		sawSingle = sawSingle || t.Multiplier != model.Triple
+	}
+	return cost
+}
+
+// setupCost returns the standard penalty for a dart thrown at t to set up a finish;
+// first marks the first dart of a visit
+func setupCost(t model.DartTarget, first bool) float64 {
+	place := float64(slices.Index(setupNumbers, t.Number))
+	if first {
+		place *= firstDartWeight
+	}
+	switch {
+	case t.Number == model.Bullseye:
+		return costSetupBull
+	case t.Multiplier == model.Triple:
+		return costSetupTreble + costTrebleNumber*place
+	case t.Multiplier == model.Double:
+		return costSetupDouble + costSingleNumber*place
+	default:
+		return costSingleNumber * place
+	}
+}
+
+// CheckoutSolver enumerates every legal finish of a score in up to three darts
+type CheckoutSolver struct {
+	Rule    OutRule
+	Policy  CheckoutPolicy
+	beds    []model.DartTarget
+	byScore map[int][]model.DartTarget // beds worth each score
+}
+
+// NewCheckoutSolver creates a solver for a standard board under the given out rule
+// and ranking policy; a nil policy uses the standard chart's
+func NewCheckoutSolver(rule OutRule, policy CheckoutPolicy) *CheckoutSolver {
+	if policy == nil {
+		policy = NewStandardCheckoutPolicy()
+	}
+	cs := &CheckoutSolver{Rule: rule, Policy: policy, byScore: make(map[int][]model.DartTarget)}
+	for _, m := range []model.Multiplier{model.Single, model.Double, model.Triple} {
+		for n := 1; n <= 20; n++ {
+			cs.addBed(model.DartTarget{Multiplier: m, Number: n})
+		}
+	}
+	cs.addBed(model.DartTarget{Multiplier: model.Single, Number: model.Bullseye})
+	cs.addBed(model.DartTarget{Multiplier: model.Double, Number: model.Bullseye})
+	return cs
+}
+
+func (cs *CheckoutSolver) addBed(t model.DartTarget) {
+	cs.beds = append(cs.beds, t)
+	cs.byScore[bedScore(t)] = append(cs.byScore[bedScore(t)], t)
+}
+
+// GetRoutes returns every legal checkout of score in up to three darts, best first.
+// Routes whose setup darts are the same beds in a different order are listed separately.
+func (cs *CheckoutSolver) GetRoutes(score int) []Out {
+	routes := cs.enumerate(score, maxCheckoutDarts)
+	ranked := make([]rankedOut, len(routes))
+	for i, r := range routes {
+		ranked[i] = cs.rank(r)
+	}
+	slices.SortFunc(ranked, compareRanked)
+	for i, r := range ranked {
+		routes[i] = r.out
+	}
+	return routes
+}
+
+// GetBest returns the policy's preferred checkout of score, or nil if there is none in three darts
+func (cs *CheckoutSolver) GetBest(score int) *Out {
+	return cs.GetBestIn(score, maxCheckoutDarts)
+}
+
+// GetBestIn returns the policy's preferred checkout of score in at most the given number of darts,
+// or nil if there is none
+func (cs *CheckoutSolver) GetBestIn(score, darts int) *Out {
+	var best *rankedOut
+	for _, r := range cs.enumerate(score, darts) {
+		ranked := cs.rank(r)
+		if best == nil || compareRanked(ranked, *best) < 0 {
+			best = &ranked
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return &best.out
+}
+
+// enumerate returns every legal checkout of score in up to the given number of darts, unordered
+func (cs *CheckoutSolver) enumerate(score, darts int) []Out {
+	var routes []Out
+	for _, finish := range cs.beds {
+		if !cs.Rule.Finishes(finish) {
+			continue
+		}
+		rest := score - bedScore(finish)
+		if rest == 0 {
+			routes = append(routes, Out{Score: score, Targets: []model.DartTarget{finish}})
+			continue
+		}
+		if darts < 2 {
+			continue
+		}
+		for _, first := range cs.byScore[rest] {
+			routes = append(routes, Out{Score: score, Targets: []model.DartTarget{first, finish}})
+		}
+		if darts < 3 {
+			continue
+		}
+		for _, first := range cs.beds {
+			for _, second := range cs.byScore[rest-bedScore(first)] {
+				routes = append(routes, Out{Score: score, Targets: []model.DartTarget{first, second, finish}})
+			}
+		}
+	}
+	return routes
+}
+
+// rankedOut is a route with its cost under the solver's policy
+type rankedOut struct {
+	out  Out
+	cost float64
+}
+
+func (cs *CheckoutSolver) rank(out Out) rankedOut {
+	return rankedOut{out: out, cost: cs.Policy.Cost(out)}
+}
+
+// compareRanked orders routes by cost, breaking ties by their notation so the order is stable
+func compareRanked(a, b rankedOut) int {
+	if a.cost != b.cost {
+		return cmp.Compare(a.cost, b.cost)
+	}
+	return strings.Compare(notation.FormatTurn(a.out.Targets), notation.FormatTurn(b.out.Targets))
+}
+
+// ValidateRoute checks that targets, if every dart hits, check out score in one visit under double-out rules
+func ValidateRoute(score int, targets []model.DartTarget) error {
+	if len(targets) == 0 || len(targets) > maxCheckoutDarts {
+		return fmt.Errorf("a checkout has 1 to %d darts, got %d", maxCheckoutDarts, len(targets))
+	}
+	rest := score
+	for i, t := range targets {
+		if !isBed(t) {
+			return fmt.Errorf("dart %d: %s is not a bed", i+1, notation.Format(t))
+		}
+		rest -= bedScore(t)
+		if i < len(targets)-1 && rest < 2 {
+			return fmt.Errorf("dart %d: %s leaves %d", i+1, notation.Format(t), rest)
+		}
+	}
+	switch {
+	case rest != 0:
+		return fmt.Errorf("%s scores %d, not %d", notation.FormatTurn(targets), score-rest, score)
+	case !DoubleOut.Finishes(targets[len(targets)-1]):
+		return fmt.Errorf("%s does not finish on a double", notation.FormatTurn(targets))
+	}
+	return nil
+}
+
+// isBed reports whether t is a bed on a standard board
+func isBed(t model.DartTarget) bool {
+	if t.Number == model.Bullseye {
+		return t.Multiplier == model.Single || t.Multiplier == model.Double
+	}
+	return t.Number >= 1 && t.Number <= 20 && t.Multiplier >= model.Single && t.Multiplier <= model.Triple
+}
+
+// bedScore returns the points for a dart in bed t
+func bedScore(t model.DartTarget) int {
+	return t.Number * int(t.Multiplier)
+}
@@ -0,0 +1,90 @@
+package oh1
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kregan77/dartbuddy/internal/model/notation"
+)
+
+// bogeys are the scores up to 170 that three darts cannot check out
+var bogeys = []int{159, 162, 163, 165, 166, 168, 169}
+
+func TestStandardChartCoversEveryFinish(t *testing.T) {
+	chart := NewOutChart()
+	for score := 2; score <= maxCheckout; score++ {
+		out := chart.GetOut(score)
+		if slices.Contains(bogeys, score) {
+			if out != nil {
+				t.Errorf("bogey %d has out %s", score, notation.FormatTurn(out.Targets))
+			}
+			continue
+		}
+		if out == nil {
+			t.Errorf("%d has no out", score)
+			continue
+		}
+		if err := ValidateRoute(score, out.Targets); err != nil {
+			t.Errorf("%d: %v", score, err)
+		}
+	}
+	for _, score := range []int{0, 1, maxCheckout + 1} {
+		if out := chart.GetOut(score); out != nil {
+			t.Errorf("%d has out %s", score, notation.FormatTurn(out.Targets))
+		}
+	}
+}
+
+func TestStandardChartRoutes(t *testing.T) {
+	chart := NewOutChart()
+	tests := []struct {
+		score, darts int
+		want         string
+	}{
+		{170, 3, "T20 T20 DB"},
+		{167, 3, "T20 T19 DB"},
+		{161, 3, "T20 T17 DB"},
+		{160, 3, "T20 T20 D20"},
+		{100, 3, "T20 D20"},
+		{40, 3, "D20"},
+		{32, 3, "D16"},
+		{50, 1, "DB"},
+	}
+	for _, tt := range tests {
+		out := chart.GetOutIn(tt.score, tt.darts)
+		if out == nil {
+			t.Errorf("%d in %d darts: no out, want %s", tt.score, tt.darts, tt.want)
+			continue
+		}
+		if got := notation.FormatTurn(out.Targets); got != tt.want {
+			t.Errorf("%d in %d darts: got %s, want %s", tt.score, tt.darts, got, tt.want)
+		}
+	}
+}
+
+func TestGetOutInWithoutFinish(t *testing.T) {
+	chart := NewOutChart()
+	for _, tt := range []struct{ score, darts int }{{99, 2}, {103, 2}, {41, 1}, {60, 1}, {171, 3}} {
+		if out := chart.GetOutIn(tt.score, tt.darts); out != nil {
+			t.Errorf("%d in %d darts: got %s, want no out", tt.score, tt.darts, notation.FormatTurn(out.Targets))
+		}
+	}
+}
+
+func TestSolverRoutesAreLegal(t *testing.T) {
+	solver := NewCheckoutSolver(DoubleOut, nil)
+	for _, score := range []int{2, 3, 41, 99, 121, 170} {
+		routes := solver.GetRoutes(score)
+		if len(routes) == 0 {
+			t.Errorf("%d: no routes", score)
+		}
+		for _, r := range routes {
+			if err := ValidateRoute(score, r.Targets); err != nil {
+				t.Errorf("%d: %v", score, err)
+			}
+		}
+		if best := solver.GetBest(score); best == nil || !slices.Equal(best.Targets, routes[0].Targets) {
+			t.Errorf("%d: GetBest does not match the first route", score)
+		}
+	}
+}
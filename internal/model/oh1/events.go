@@ -0,0 +1,197 @@
+package oh1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+)
+
+// Event is something that happened during a game, delivered to the game's observers.
+// It is one of PlayerJoined, AwaitingPlayer, TurnStarted, DartThrown, TurnScored, Bust or LegWon.
+type Event interface {
+	// Name identifies the kind of event, e.g. "dart_thrown"
+	Name() string
+}
+
+// PlayerJoined is sent when a player is added to the game
+type PlayerJoined struct {
+	Player  string
+	ThreeDA float64
+	Spread  float64 // simulated throw spread in mm calibrated from ThreeDA
+}
+
+// AwaitingPlayer is sent when PlayTurn is called for a real player, whose darts must be submitted
+type AwaitingPlayer struct {
+	Player string
+}
+
+// TurnStarted is sent before a player's first dart of a turn
+type TurnStarted struct {
+	Player  string
+	Turn    int // the game's turn number
+	Score   int // the player's score before the turn
+	ThreeDA float64
+}
+
+// DartThrown is sent for every dart, simulated or submitted
+type DartThrown struct {
+	Player    string
+	Dart      int               // 0, 1 or 2 within the turn
+	Score     int               // the player's score before the dart
+	Target    model.DartTarget  // what the player went for; zero for submitted darts
+	Aim       *model.AimPoint   // the solved aim point for players who aim optimally, otherwise nil
+	Replayed  bool              // the dart was sampled from the player's recorded history
+	Submitted bool              // the dart was thrown on a real board
+	Result    *model.DartResult // what the dart hit
+}
+
+// TurnScored is sent when a turn ends without a bust or a win
+type TurnScored struct {
+	Player    string
+	Scored    int
+	Remaining int
+	ThreeDA   float64
+}
+
+// Bust is sent when a player busts; their score goes back to what it was before the turn
+type Bust struct {
+	Player string
+	Score  int
+}
+
+// LegWon is sent when a player checks out
+type LegWon struct {
+	Player  string
+	Scored  int // points scored in the winning turn
+	Darts   int // darts the player threw in the leg
+	ThreeDA float64
+}
+
+func (PlayerJoined) Name() string   { return "player_joined" }
+func (AwaitingPlayer) Name() string { return "awaiting_player" }
+func (TurnStarted) Name() string    { return "turn_started" }
+func (DartThrown) Name() string     { return "dart_thrown" }
+func (TurnScored) Name() string     { return "turn_scored" }
+func (Bust) Name() string           { return "bust" }
+func (LegWon) Name() string         { return "leg_won" }
+
+// Observer receives a game's events as they happen
+type Observer interface {
+	OnEvent(e Event)
+}
+
+// ObserverFunc adapts a function to an Observer
+type ObserverFunc func(e Event)
+
+func (f ObserverFunc) OnEvent(e Event) {
+	f(e)
+}
+
+// AddObserver registers an observer for the game's events.
+// Observers are called synchronously, in the order they were added.
+func (g *Game) AddObserver(o Observer) {
+	g.observers = append(g.observers, o)
+}
+
+// emit delivers an event to every observer
+func (g *Game) emit(e Event) {
+	for _, o := range g.observers {
+		o.OnEvent(e)
+	}
+}
+
+// printer narrates a game as plain text
+type printer struct {
+	w io.Writer
+}
+
+// NewPrinter returns an observer that narrates the game to w, e.g. os.Stdout for the CLI
+func NewPrinter(w io.Writer) Observer {
+	return &printer{w: w}
+}
+
+func (p *printer) OnEvent(e Event) {
+	switch e := e.(type) {
+	case AwaitingPlayer:
+		fmt.Fprintf(p.w, "awaiting real player score submission for %s...\n", e.Player)
+	case TurnStarted:
+		fmt.Fprintf(p.w, "%s turn.  Current Score: %d; Leg 3DA: %.2f\n", e.Player, e.Score, e.ThreeDA)
+	case DartThrown:
+		switch {
+		case e.Submitted:
+			fmt.Fprintf(p.w, "\tDart %d: %s\n", e.Dart+1, e.Result)
+		case e.Replayed:
+			fmt.Fprintf(p.w, "\tDart %d(target: %s, replayed): %s\n", e.Dart+1, &e.Target, e.Result)
+		case e.Aim != nil:
+			fmt.Fprintf(p.w, "\tDart %d(target: %s @ %.1f,%.1f): %s\n", e.Dart+1, &e.Target, e.Aim.X, e.Aim.Y, e.Result)
+		default:
+			fmt.Fprintf(p.w, "\tDart %d(target: %s): %s\n", e.Dart+1, &e.Target, e.Result)
+		}
+	case TurnScored:
+		fmt.Fprintf(p.w, "Player %s scored %d points(3DA: %.2f) remaining score: %d\n\n",
+			e.Player, e.Scored, e.ThreeDA, e.Remaining)
+	case Bust:
+		fmt.Fprintf(p.w, "\tBUST!  Score resets to %d\n", e.Score)
+		fmt.Fprintf(p.w, "Player %s busted\n\n", e.Player)
+	case LegWon:
+		fmt.Fprintf(p.w, "\tWIN!!\n")
+		fmt.Fprintf(p.w, "Player %s scored %d points for the win(3DA: %.2f)!\n\n", e.Player, e.Scored, e.ThreeDA)
+	}
+}
+
+// slogObserver logs a game's events
+type slogObserver struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+// NewSlogObserver returns an observer that logs every event to logger at the given level,
+// with the event's fields as attributes
+func NewSlogObserver(logger *slog.Logger, level slog.Level) Observer {
+	return &slogObserver{logger: logger, level: level}
+}
+
+func (s *slogObserver) OnEvent(e Event) {
+	ctx := context.Background()
+	if !s.logger.Enabled(ctx, s.level) {
+		return
+	}
+	var attrs []slog.Attr
+	switch e := e.(type) {
+	case PlayerJoined:
+		attrs = []slog.Attr{slog.String("player", e.Player),
+			slog.Float64("three_da", e.ThreeDA), slog.Float64("spread", e.Spread)}
+	case AwaitingPlayer:
+		attrs = []slog.Attr{slog.String("player", e.Player)}
+	case TurnStarted:
+		attrs = []slog.Attr{slog.String("player", e.Player), slog.Int("turn", e.Turn),
+			slog.Int("score", e.Score), slog.Float64("three_da", e.ThreeDA)}
+	case DartThrown:
+		attrs = []slog.Attr{slog.String("player", e.Player), slog.Int("dart", e.Dart+1),
+			slog.Int("score", e.Score), slog.String("result", e.Result.String())}
+		if !e.Submitted {
+			attrs = append(attrs, slog.String("target", e.Target.String()))
+		}
+		if e.Aim != nil {
+			attrs = append(attrs, slog.Float64("aim_x", e.Aim.X), slog.Float64("aim_y", e.Aim.Y))
+		}
+		if e.Replayed {
+			attrs = append(attrs, slog.Bool("replayed", true))
+		}
+		if e.Submitted {
+			attrs = append(attrs, slog.Bool("submitted", true))
+		}
+	case TurnScored:
+		attrs = []slog.Attr{slog.String("player", e.Player), slog.Int("scored", e.Scored),
+			slog.Int("remaining", e.Remaining), slog.Float64("three_da", e.ThreeDA)}
+	case Bust:
+		attrs = []slog.Attr{slog.String("player", e.Player), slog.Int("score", e.Score)}
+	case LegWon:
+		attrs = []slog.Attr{slog.String("player", e.Player), slog.Int("scored", e.Scored), slog.Int("darts", e.Darts),
+			slog.Float64("three_da", e.ThreeDA)}
+	}
+	s.logger.LogAttrs(ctx, s.level, e.Name(), attrs...)
+}
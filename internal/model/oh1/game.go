@@ -3,6 +3,7 @@ package oh1
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/google/uuid"
 	"github.com/kregan77/dartbuddy/internal/model"
@@ -12,17 +13,26 @@ import (
 type Player struct {
 	model.PlayerProfile
 	spread       float64
+	aims         map[aimKey]model.AimPoint // solved aim points
+	lastTurn     int                       // score of the player's previous turn
+	Performance  PerformanceModel          // varies accuracy with the situation; nil means constant
+	Outs         *OutChart                 // the player's own checkout chart; nil uses the game's
 	CurrentScore int
 	Turns        int
 	TotalPoints  int
 	Throws       int
 }
 
+// GetSpread returns the simulated throw spread (mm) for the player
+func (p *Player) GetSpread() float64 {
+	return p.spread
+}
+
 func (p *Player) CurrentThreeDA() float64 {
 	if p.Throws == 0 {
 		return 0.0
 	}
-	return float64(p.TotalPoints) / float64(p.Throws/3)
+	return float64(p.TotalPoints) / float64(p.Throws) * 3.0
 }
 
 type Game struct {
@@ -33,14 +43,34 @@ type Game struct {
 	StartScore    int
 	Turn          int
 	Outs          *OutChart
+	Seed          int64   // seed of the game's simulator; replaying with it reproduces every simulated dart
+	Finished      bool    // true once a player has checked out
+	Winner        *Player // the player who checked out; nil until the leg is finished
+	observers     []Observer
+	profileOuts   map[*model.PlayerProfile]*OutChart // charts built from profiles' checkout preferences
+	aimSim        *model.Simulator                   // solves aim points; nil uses Simulator
 }
 
 func New01Game(startingScore int) *Game {
+	return New01GameWithSimulator(startingScore, model.NewSimulator())
+}
+
+// New01GameWithSeed creates a game whose simulated darts are reproducible from seed.
+// Adding the same players in the same order and playing the same turns yields
+// identical results dart-for-dart.
+func New01GameWithSeed(startingScore int, seed int64) *Game {
+	return New01GameWithSimulator(startingScore, model.NewSeededSimulator(seed))
+}
+
+// New01GameWithSimulator creates a game that throws simulated darts with sim,
+// e.g. one built for a non-standard board.
+func New01GameWithSimulator(startingScore int, sim *model.Simulator) *Game {
 	return &Game{
 		ID:         uuid.New(),
 		StartScore: startingScore,
-		Simulator:  model.NewSimulator(),
+		Simulator:  sim,
 		Outs:       NewOutChart(),
+		Seed:       sim.GetSeed(),
 	}
 }
 
@@ -52,13 +82,67 @@ func (g *Game) Start() error {
 	return nil
 }
 
+// resetLeg starts a new leg with the same players, first to throw
+func (g *Game) resetLeg(first int) {
+	for _, p := range g.Players {
+		p.CurrentScore = g.StartScore
+		p.Turns = 0
+		p.TotalPoints = 0
+		p.Throws = 0
+		p.lastTurn = 0
+	}
+	g.Turn = 0
+	g.CurrentPlayer = first
+	g.Finished = false
+	g.Winner = nil
+}
+
+// Finish ends the leg with p as the winner; later turns return ErrLegOver
+func (g *Game) Finish(p *Player) {
+	g.Finished = true
+	g.Winner = p
+}
+
 // TODO: for now the game will go in order of player added.
-func (g *Game) AddPlayer(profile *model.PlayerProfile) {
+func (g *Game) AddPlayer(profile *model.PlayerProfile) *Player {
 	player := &Player{
 		PlayerProfile: *profile,
-		CurrentScore:  g.StartScore,
+		spread: g.Simulator.GetCalibratedSpread(profile.GetThreeDA(),
+			profile.GetThrowModel(), profile.GetScoringPreference()),
+		CurrentScore: g.StartScore,
+	}
+	if profile.HasCheckoutPreferences() {
+		player.Outs = g.getProfileOuts(profile)
 	}
 	g.Players = append(g.Players, player)
+	g.emit(PlayerJoined{Player: player.GetName(), ThreeDA: player.GetThreeDA(), Spread: player.spread})
+	return player
+}
+
+// getProfileOuts returns the chart for a profile's checkout preferences, building it on first use
+func (g *Game) getProfileOuts(profile *model.PlayerProfile) *OutChart {
+	if chart, ok := g.profileOuts[profile]; ok {
+		return chart
+	}
+	chart := NewProfileOutChart(profile)
+	if g.profileOuts == nil {
+		g.profileOuts = make(map[*model.PlayerProfile]*OutChart)
+	}
+	g.profileOuts[profile] = chart
+	return chart
+}
+
+// GetOuts returns the checkout chart used for p: their own if they have one, otherwise the game's
+func (g *Game) GetOuts(p *Player) *OutChart {
+	if p.Outs != nil {
+		return p.Outs
+	}
+	return g.Outs
+}
+
+// UsePlayerOuts gives p a checkout chart tailored to their accuracy on the game's board
+func (g *Game) UsePlayerOuts(p *Player) {
+	p.Outs = NewPlayerOutChart(g.Simulator, &p.PlayerProfile, p.GetSpread())
 }
 
 func (g *Game) GetCurrentPlayer() *Player {
@@ -89,33 +173,87 @@ type TurnResult struct {
 	CurrentThreeDA float64
 }
 
-func (g *Game) PlayTurn() *TurnResult {
+// ErrLegOver is returned for turns played after a player has checked out
+var ErrLegOver = errors.New("the leg is over")
+
+// ErrNotSimulated is returned by PlayTurn when the current player throws on a real board
+var ErrNotSimulated = errors.New("current player is not simulated")
+
+// PlayTurn plays the current simulated player's turn. It returns ErrNotSimulated if the
+// current player is real, and ErrLegOver once the leg has been won.
+func (g *Game) PlayTurn() (*TurnResult, error) {
+	if g.Finished {
+		return nil, ErrLegOver
+	}
 	p := g.GetCurrentPlayer()
 	if p.GetType() == model.RealPlayer {
-		fmt.Printf("awaiting real player score submission for %s...\n", p.GetName())
-		return nil
+		g.emit(AwaitingPlayer{Player: p.GetName()})
+		return nil, ErrNotSimulated
+	}
+
+	g.emitTurnStarted(p)
+	g.Simulator.StartTurn()
+	defer g.Simulator.EndTurn()
+	return g.playDarts(p, 3, func(dart, currentScore int) *model.DartResult {
+		return g.ThrowDart(dart, currentScore, p)
+	}), nil
+}
+
+// SubmitDarts scores darts the current player threw on a real board, e.g. as reported by
+// an autoscoring camera, applying the same x01 rules as simulated turns.
+// One to three darts may be submitted; the player's turn ends afterwards.
+// It returns ErrLegOver once the leg has been won.
+func (g *Game) SubmitDarts(darts []*model.DartResult) (*TurnResult, error) {
+	if len(g.Players) == 0 {
+		return nil, errors.New("cannot submit darts with no players")
+	}
+	if g.Finished {
+		return nil, ErrLegOver
+	}
+	if len(darts) == 0 || len(darts) > 3 {
+		return nil, fmt.Errorf("a turn has 1 to 3 darts, got %d", len(darts))
 	}
 
-	fmt.Printf("%s turn.  Current Score: %d; Leg 3DA: %.2f\n",
-		p.GetName(), p.CurrentScore, p.CurrentThreeDA())
+	p := g.GetCurrentPlayer()
+	g.emitTurnStarted(p)
+	return g.playDarts(p, len(darts), func(dart, currentScore int) *model.DartResult {
+		g.emit(DartThrown{Player: p.GetName(), Dart: dart, Score: currentScore, Submitted: true, Result: darts[dart]})
+		return darts[dart]
+	}), nil
+}
+
+// emitTurnStarted tells the observers that p is about to throw
+func (g *Game) emitTurnStarted(p *Player) {
+	g.emit(TurnStarted{Player: p.GetName(), Turn: g.Turn, Score: p.CurrentScore, ThreeDA: p.CurrentThreeDA()})
+}
+
+// playDarts plays up to count darts for p, taking each from throw, and applies the
+// x01 rules: a bust restores the score, checking out on a double wins.
+func (g *Game) playDarts(p *Player, count int, throw func(dart, currentScore int) *model.DartResult) *TurnResult {
+	p.Turns++
 	totalScore := 0
 	currentScore := p.CurrentScore
-	results := make([]*model.DartResult, 0, 3)
-	for dart := range 3 {
-		result := g.ThrowDart(dart, currentScore, p)
+	results := make([]*model.DartResult, 0, count)
+	for dart := range count {
+		result := throw(dart, currentScore)
 
 		results = append(results, result)
+		bust := isBust(currentScore, *result)
 		currentScore -= result.Score
-		if currentScore < 0 ||
-			(currentScore == 0 && result.GetMultiplier() != model.Double) ||
-			currentScore == 1 {
-			fmt.Printf("	BUST!  Score resets to %d\n", p.CurrentScore)
+		if bust {
+			g.emit(Bust{Player: p.GetName(), Score: p.CurrentScore})
+			// a bust scores nothing, but the darts still count
+			p.TotalPoints -= totalScore
+			p.Throws++
+			p.lastTurn = 0
+			g.Turn++
+			g.NextPlayer()
 			return &TurnResult{
 				Type:           BustTurn,
 				PlayerName:     p.GetName(),
 				Results:        results,
-				TotalScore:     totalScore,
 				CurrentThreeDA: p.CurrentThreeDA(),
+				RemainingScore: p.CurrentScore,
 			}
 		}
 		// either win or continue
@@ -124,7 +262,9 @@ func (g *Game) PlayTurn() *TurnResult {
 		totalScore += result.Score
 
 		if currentScore == 0 {
-			fmt.Println("	WIN!!")
+			p.CurrentScore = currentScore
+			g.Finish(p)
+			g.emit(LegWon{Player: p.GetName(), Scored: totalScore, Darts: p.Throws, ThreeDA: p.CurrentThreeDA()})
 			return &TurnResult{
 				Type:           WinTurn,
 				PlayerName:     p.GetName(),
@@ -137,6 +277,8 @@ func (g *Game) PlayTurn() *TurnResult {
 	}
 
 	p.CurrentScore = currentScore
+	p.lastTurn = totalScore
+	g.emit(TurnScored{Player: p.GetName(), Scored: totalScore, Remaining: currentScore, ThreeDA: p.CurrentThreeDA()})
 	g.Turn++
 	g.NextPlayer()
 	return &TurnResult{
@@ -144,26 +286,155 @@ func (g *Game) PlayTurn() *TurnResult {
 		PlayerName:     p.GetName(),
 		TotalScore:     totalScore,
 		Results:        results,
-		CurrentThreeDA: float64(p.TotalPoints) / float64(p.Throws/3),
+		CurrentThreeDA: p.CurrentThreeDA(),
 		RemainingScore: p.CurrentScore,
 	}
 }
 
+// ThrowDart throws dart (0 for the first of the turn) for p on currentScore, at the target
+// their checkout chart gives for the darts still in hand
 func (g *Game) ThrowDart(dart int, currentScore int, p *Player) *model.DartResult {
-	target := g.Outs.GetNextTarget(currentScore, p.GetScoringPreference())
-	result := g.Simulator.ThrowDart(target, p.GetSpread())
-	fmt.Printf("	Dart %d(target: %s): %s\n", dart+1,
-		target.String(),
-		result.String())
+	preference := p.GetScoringPreference().Resolve(g.Simulator.IsBlocked)
+	target := g.GetOuts(p).GetNextTarget(currentScore, maxCheckoutDarts-dart, preference)
+	if p.ReplayHistory != nil && p.ReplayHistory.Len() > 0 {
+		result := g.Simulator.ReplayDart(p.ReplayHistory, target)
+		g.emit(DartThrown{Player: p.GetName(), Dart: dart, Score: currentScore, Target: target, Replayed: true, Result: result})
+		return result
+	}
+	spread := g.getSituationalSpread(dart, currentScore, target, p)
+	if p.OptimalAim {
+		aim := g.getAimPoint(currentScore, target, preference, spread, p)
+		result := g.Simulator.ThrowAtForPlayer(&p.PlayerProfile, aim.X, aim.Y, spread)
+		g.emit(DartThrown{Player: p.GetName(), Dart: dart, Score: currentScore, Target: target, Aim: &aim, Result: result})
+		return result
+	}
+	result := g.Simulator.ThrowDartForPlayer(&p.PlayerProfile, target, spread)
+	g.emit(DartThrown{Player: p.GetName(), Dart: dart, Score: currentScore, Target: target, Result: result})
 	return result
 }
 
+// getSituationalSpread applies the player's performance model to their base spread
+func (g *Game) getSituationalSpread(dart int, currentScore int, target model.DartTarget, p *Player) float64 {
+	if p.Performance == nil {
+		return p.GetSpread()
+	}
+	situation := ThrowSituation{
+		Dart:          dart,
+		Turn:          p.Turns,
+		CurrentScore:  currentScore,
+		Target:        target,
+		ForTheGame:    target.Multiplier == model.Double && target.Number*2 == currentScore,
+		LastTurnScore: p.lastTurn,
+		ExpectedTurn:  p.GetThreeDA(),
+	}
+	for _, other := range g.Players {
+		if other != p && g.GetOuts(other).GetOut(other.CurrentScore) != nil {
+			situation.OpponentOnFinish = true
+		}
+	}
+	return p.GetSpread() * p.Performance.SpreadFactor(situation)
+}
+
+// scoringAimKey caches the aim point for pure scoring darts, which have no single intended bed
+var scoringAimKey = model.DartTarget{Multiplier: model.Miss}
+
+// aimSpreadRatio is the ratio between neighbouring spreads aim points are solved for;
+// spreads are rounded to the nearest one so a handful of solutions serve every situation
+const aimSpreadRatio = 1.1
+
+// maxBedScore is the most a single dart can score
+const maxBedScore = 60
+
+// aimKey identifies a solved aim point: the intended target, the score for objectives that
+// depend on it, and the rounded spread
+type aimKey struct {
+	target model.DartTarget
+	score  int
+	spread float64
+}
+
+// getAimPoint returns where an optimally-aiming player throwing with spread should aim for
+// the target. A twenties player scoring on the treble 20 with no checkout on the board
+// maximises expected points, wherever that takes them. A setup dart on a score low enough
+// for a stray dart to bust also weighs the chance of not busting; otherwise the player
+// maximises the chance of hitting the target. Solutions are cached per player.
+func (g *Game) getAimPoint(currentScore int, target model.DartTarget, preference model.ScoringPreference, spread float64, p *Player) model.AimPoint {
+	key := aimKey{target: target}
+	switch {
+	case preference == model.TwentiesScoringPreference && target == preference.GetTarget() &&
+		g.GetOuts(p).GetOut(currentScore) == nil:
+		key.target = scoringAimKey
+	case !isFinish(currentScore, target) && currentScore-maxBedScore < 2:
+		key.score = currentScore
+	}
+	spread = p.GetTargetSpread(target, spread)
+	key.spread = math.Pow(aimSpreadRatio, math.Round(math.Log(spread)/math.Log(aimSpreadRatio)))
+	if aim, ok := p.aims[key]; ok {
+		return aim
+	}
+
+	solver := g.Simulator
+	if g.aimSim != nil {
+		solver = g.aimSim
+	}
+	var aim model.AimPoint
+	if key.target == scoringAimKey {
+		aim = solver.SolveAim(&p.PlayerProfile, key.spread, model.ExpectedPointsObjective())
+	} else {
+		objective := model.HitTargetObjective(target)
+		if key.score != 0 {
+			objective = setupObjective(currentScore, target)
+		}
+		centre := solver.GetTargetPoint(target)
+		aim = solver.SolveAimNear(&p.PlayerProfile, key.spread, objective, centre.X, centre.Y, aimSearchHalfWidth)
+	}
+	if p.aims == nil {
+		p.aims = make(map[aimKey]model.AimPoint)
+	}
+	p.aims[key] = aim
+	return aim
+}
+
+// aimSearchHalfWidth bounds the search (mm) around a bed when aiming to hit it
+const aimSearchHalfWidth = 40.0
+
+// NoBustObjective values a dart by whether it leaves the player able to continue or win
+// from currentScore under double-out rules
+func NoBustObjective(currentScore int) model.AimObjective {
+	return func(result model.DartResult) float64 {
+		if isBust(currentScore, result) {
+			return 0
+		}
+		return 1
+	}
+}
+
+// setupObjective values a setup dart from currentScore equally by hitting target and by not busting
+func setupObjective(currentScore int, target model.DartTarget) model.AimObjective {
+	hit, safe := model.HitTargetObjective(target), NoBustObjective(currentScore)
+	return func(result model.DartResult) float64 {
+		return hit(result) + safe(result)
+	}
+}
+
+// isFinish reports whether hitting target checks out currentScore under double-out rules
+func isFinish(currentScore int, target model.DartTarget) bool {
+	return target.Multiplier == model.Double && bedScore(target) == currentScore
+}
+
+// isBust reports whether the dart busts a player on currentScore under double-out rules
+func isBust(currentScore int, result model.DartResult) bool {
+	remaining := currentScore - result.Score
+	return remaining < 0 ||
+		(remaining == 0 && result.GetMultiplier() != model.Double) ||
+		remaining == 1
+}
+
 func (g *Game) GetGameSummary() string {
 	summary := "Game Summary:\n"
 	for _, p := range g.Players {
 		summary += fmt.Sprintf("%s:\n\tFinal Score: %d, Total Points: %d, Throws: %d, 3DA: %.2f\n",
-			p.GetName(), p.CurrentScore, p.TotalPoints, p.Throws,
-			float64(p.TotalPoints)/float64(p.Throws/3))
+			p.GetName(), p.CurrentScore, p.TotalPoints, p.Throws, p.CurrentThreeDA())
 	}
 	return summary
 }
@@ -0,0 +1,125 @@
+package oh1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+)
+
+// newSeededTestGame creates a 501 game with two simulated players and the given seed
+func newSeededTestGame(seed int64) *Game {
+	g := New01GameWithSeed(501, seed)
+	for _, name := range []string{"A", "B"} {
+		profile := model.NewPlayer(name, 60, model.TwentiesScoringPreference)
+		profile.PlayerType = model.SimulatedPlayer
+		g.AddPlayer(profile)
+	}
+	return g
+}
+
+// playTestTurn plays the current player's turn, failing the test if it cannot be played
+func playTestTurn(t *testing.T, g *Game) *TurnResult {
+	t.Helper()
+	r, err := g.PlayTurn()
+	if err != nil {
+		t.Fatalf("turn %d: %v", g.Turn, err)
+	}
+	return r
+}
+
+func TestSeededTurnsAreReproducible(t *testing.T) {
+	first, second := newSeededTestGame(42), newSeededTestGame(42)
+	for turn := range 10 {
+		a, b := playTestTurn(t, first), playTestTurn(t, second)
+		if a.Type != b.Type || a.TotalScore != b.TotalScore || a.RemainingScore != b.RemainingScore {
+			t.Fatalf("turn %d: got %+v and %+v", turn, *a, *b)
+		}
+		if len(a.Results) != len(b.Results) {
+			t.Fatalf("turn %d: %d and %d darts", turn, len(a.Results), len(b.Results))
+		}
+		for i := range a.Results {
+			da, db := a.Results[i], b.Results[i]
+			if da.DartTarget != db.DartTarget || da.Score != db.Score || da.BounceOut != db.BounceOut || da.Landing != db.Landing {
+				t.Fatalf("turn %d dart %d: got %v and %v", turn, i, da, db)
+			}
+		}
+		if a.Type == WinTurn {
+			break
+		}
+	}
+	for i, p := range first.Players {
+		if q := second.Players[i]; p.CurrentScore != q.CurrentScore || p.TotalPoints != q.TotalPoints || p.Throws != q.Throws {
+			t.Errorf("%s: scores differ: %d/%d/%d and %d/%d/%d", p.GetName(),
+				p.CurrentScore, p.TotalPoints, p.Throws, q.CurrentScore, q.TotalPoints, q.Throws)
+		}
+	}
+}
+
+func TestDifferentSeedsDiffer(t *testing.T) {
+	first, second := newSeededTestGame(1), newSeededTestGame(2)
+	a, b := playTestTurn(t, first), playTestTurn(t, second)
+	if a.Results[0].Landing == b.Results[0].Landing {
+		t.Errorf("seeds 1 and 2 landed the first dart at the same point %+v", a.Results[0].Landing)
+	}
+}
+
+func TestCoverSwitchesToNineteensWhenTrebleTwentyIsBlocked(t *testing.T) {
+	g := New01GameWithSeed(501, 1)
+	g.AddPlayer(model.NewPlayer("A", 60, model.CoverScoringPreference))
+	p := g.Players[0]
+	t20 := model.DartTarget{Multiplier: model.Triple, Number: 20}
+	t19 := model.DartTarget{Multiplier: model.Triple, Number: 19}
+	nextTarget := func() model.DartTarget {
+		preference := p.GetScoringPreference().Resolve(g.Simulator.IsBlocked)
+		return g.GetOuts(p).GetNextTarget(401, 2, preference)
+	}
+
+	g.Simulator.StartTurn()
+	if got := nextTarget(); got != t20 {
+		t.Fatalf("empty board: next target %v, want %v", got, t20)
+	}
+	if result := g.Simulator.ThrowDart(t20, 0.1); result.DartTarget != t20 {
+		t.Fatalf("first dart landed in %v, want %v", result.DartTarget, t20)
+	}
+	if got := nextTarget(); got != t19 {
+		t.Errorf("dart in T20: next target %v, want %v", got, t19)
+	}
+	g.Simulator.EndTurn()
+	if got := nextTarget(); got != t20 {
+		t.Errorf("after the turn: next target %v, want %v", got, t20)
+	}
+}
+
+func TestTurnsAfterTheLegIsWonAreRejected(t *testing.T) {
+	g := New01GameWithSeed(41, 1)
+	profile := model.NewPlayer("A", 60, model.TwentiesScoringPreference)
+	profile.PlayerType = model.SimulatedPlayer
+	g.AddPlayer(profile)
+	for turn := 0; !g.Finished; turn++ {
+		if turn == 100 {
+			t.Fatal("nobody checked out 41 in 100 turns")
+		}
+		playTestTurn(t, g)
+	}
+	if g.Winner != g.Players[0] || g.Winner.CurrentScore != 0 {
+		t.Fatalf("winner %v on %d, want A on 0", g.Winner, g.Players[0].CurrentScore)
+	}
+
+	if r, err := g.PlayTurn(); !errors.Is(err, ErrLegOver) {
+		t.Errorf("PlayTurn after the win = %v, %v, want ErrLegOver", r, err)
+	}
+	dart := &model.DartResult{DartTarget: model.DartTarget{Multiplier: model.Single, Number: 1}, Score: 1}
+	if r, err := g.SubmitDarts([]*model.DartResult{dart}); !errors.Is(err, ErrLegOver) {
+		t.Errorf("SubmitDarts after the win = %v, %v, want ErrLegOver", r, err)
+	}
+	if g.Players[0].CurrentScore != 0 {
+		t.Errorf("score changed to %d after the win", g.Players[0].CurrentScore)
+	}
+
+	g.resetLeg(0)
+	if g.Finished || g.Winner != nil {
+		t.Error("a new leg is still finished")
+	}
+	playTestTurn(t, g)
+}
@@ -0,0 +1,129 @@
+package oh1
+
+import (
+	"math"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+)
+
+// ThrowSituation describes the circumstances a simulated dart is thrown in
+type ThrowSituation struct {
+	Dart             int              // dart of the turn, 0-2
+	Turn             int              // the player's turn in the leg, starting at 1
+	CurrentScore     int              // score remaining before this dart
+	Target           model.DartTarget // what the player is aiming at
+	ForTheGame       bool             // hitting the target wins the leg
+	OpponentOnFinish bool             // an opponent is sitting on a checkout
+	LastTurnScore    int              // what the player scored with their previous turn
+	ExpectedTurn     float64          // the player's expected turn score (their 3DA)
+}
+
+// PerformanceModel varies a player's accuracy with the situation of each dart.
+// SpreadFactor multiplies the player's base spread: above 1 is worse, below 1 is better.
+type PerformanceModel interface {
+	SpreadFactor(situation ThrowSituation) float64
+}
+
+// StandardPerformance is a configurable performance model covering rhythm within a turn,
+// fatigue over a long leg, pressure and checkout nerves, and momentum between turns.
+// The zero value has no effect.
+type StandardPerformance struct {
+	DartFactors            [3]float64 // per-dart-in-turn factor; zero means 1
+	FatigueAfterTurns      int        // turns before fatigue sets in
+	FatiguePerTurn         float64    // spread added per turn beyond FatigueAfterTurns, as a fraction
+	MaxFatigue             float64    // cap on the fatigue fraction
+	MatchDartPressure      float64    // spread added, as a fraction, on a dart that would win the leg
+	OpponentFinishPressure float64    // spread added, as a fraction, when an opponent is on a finish
+	DoubleNerves           float64    // spread added, as a fraction, when aiming at a double to check out
+	Momentum               float64    // how strongly last turn's score over/under expectation carries over
+}
+
+// NewStandardPerformance returns a performance model with typical club-player effects
+func NewStandardPerformance() *StandardPerformance {
+	return &StandardPerformance{
+		DartFactors:            [3]float64{1.03, 0.99, 1.0},
+		FatigueAfterTurns:      8,
+		FatiguePerTurn:         0.015,
+		MaxFatigue:             0.15,
+		MatchDartPressure:      0.12,
+		OpponentFinishPressure: 0.05,
+		DoubleNerves:           0.08,
+		Momentum:               0.1,
+	}
+}
+
+// NewSteadyPerformance returns a performance model for a player who barely feels pressure
+func NewSteadyPerformance() *StandardPerformance {
+	return &StandardPerformance{
+		DartFactors:            [3]float64{1.01, 1.0, 1.0},
+		FatigueAfterTurns:      12,
+		FatiguePerTurn:         0.005,
+		MaxFatigue:             0.05,
+		MatchDartPressure:      0.03,
+		OpponentFinishPressure: 0.01,
+		DoubleNerves:           0.02,
+		Momentum:               0.03,
+	}
+}
+
+// NewStreakyPerformance returns a performance model for a player who chokes on the big
+// darts and runs hot and cold
+func NewStreakyPerformance() *StandardPerformance {
+	return &StandardPerformance{
+		DartFactors:            [3]float64{1.05, 0.98, 1.0},
+		FatigueAfterTurns:      6,
+		FatiguePerTurn:         0.025,
+		MaxFatigue:             0.25,
+		MatchDartPressure:      0.3,
+		OpponentFinishPressure: 0.12,
+		DoubleNerves:           0.2,
+		Momentum:               0.3,
+	}
+}
+
+func (sp *StandardPerformance) SpreadFactor(situation ThrowSituation) float64 {
+	factor := 1.0
+	if situation.Dart >= 0 && situation.Dart < len(sp.DartFactors) && sp.DartFactors[situation.Dart] != 0 {
+		factor *= sp.DartFactors[situation.Dart]
+	}
+
+	if extra := situation.Turn - sp.FatigueAfterTurns; extra > 0 {
+		factor *= 1 + math.Min(float64(extra)*sp.FatiguePerTurn, sp.MaxFatigue)
+	}
+
+	if situation.ForTheGame {
+		factor *= 1 + sp.MatchDartPressure
+	}
+	if situation.OpponentOnFinish {
+		factor *= 1 + sp.OpponentFinishPressure
+	}
+	if situation.Target.Multiplier == model.Double && situation.CurrentScore <= 170 {
+		factor *= 1 + sp.DoubleNerves
+	}
+
+	// Momentum: a good last turn tightens the grouping, a poor one loosens it
+	if situation.Turn > 1 && situation.ExpectedTurn > 0 {
+		form := (float64(situation.LastTurnScore) - situation.ExpectedTurn) / situation.ExpectedTurn
+		form = math.Max(-1, math.Min(1, form))
+		factor *= 1 - sp.Momentum*form
+	}
+
+	return math.Max(factor, 0.1)
+}
+
+// PerformanceByName returns the preset performance model with the given name
+// ("none", "steady", "standard" or "streaky")
+func PerformanceByName(name string) (PerformanceModel, bool) {
+	switch name {
+	case "", "none":
+		return nil, true
+	case "steady":
+		return NewSteadyPerformance(), true
+	case "standard":
+		return NewStandardPerformance(), true
+	case "streaky":
+		return NewStreakyPerformance(), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,74 @@
+package oh1
+
+import "github.com/kregan77/dartbuddy/internal/model"
+
+// playerOutSamples is the number of simulated darts thrown at each bed to learn where a player's darts land
+const playerOutSamples = 2000
+
+// NewPlayerOutChart creates a checkout chart tailored to a player throwing with spread on sim's board.
+// For every score and number of darts in hand it picks the first target that gives the best
+// chance of checking out this turn, allowing for where the player's darts really land, and
+// follows it with the best targets for what hitting it would leave. Only targets that finish
+// or leave a finish when hit are considered. Each Out's Probability is that chance.
+// Scores that cannot be checked out with the darts in hand have no out, and are set up
+// for the player's preferred doubles. The player's route overrides take precedence.
+func NewPlayerOutChart(sim *model.Simulator, player *model.PlayerProfile, spread float64) *OutChart {
+	beds := NewCheckoutSolver(DoubleOut, nil).beds
+	hits := make([]map[model.DartTarget]float64, len(beds))
+	for i, bed := range beds {
+		hits[i] = sim.HitProbabilities(player, bed, spread, playerOutSamples)
+	}
+
+	// chance[d][s] is the best chance of checking out s with d darts in hand,
+	// and best[d][s] the index in beds of the first target that achieves it
+	var chance [maxCheckoutDarts + 1][maxCheckout + 1]float64
+	var best [maxCheckoutDarts + 1][maxCheckout + 1]int
+	for darts := 1; darts <= maxCheckoutDarts; darts++ {
+		for score := 2; score <= maxCheckout; score++ {
+			for i, bed := range beds {
+				rest := score - bedScore(bed)
+				finishes := rest == 0 && DoubleOut.Finishes(bed)
+				if !finishes && (rest < 2 || chance[darts-1][rest] == 0) {
+					continue
+				}
+				p := 0.0
+				for hit, q := range hits[i] {
+					rest := score - bedScore(hit)
+					switch {
+					case rest == 0 && DoubleOut.Finishes(hit):
+						p += q
+					case rest >= 2:
+						p += q * chance[darts-1][rest]
+					}
+				}
+				if p > chance[darts][score] {
+					chance[darts][score] = p
+					best[darts][score] = i
+				}
+			}
+		}
+	}
+
+	planner := standardChart().planner
+	if len(player.PreferredDoubles) > 0 {
+		planner = NewSetupPlanner(NewCheckoutSolver(DoubleOut, NewProfileCheckoutPolicy(player)))
+	}
+	chart := newOutChart(planner)
+	for darts := 1; darts <= maxCheckoutDarts; darts++ {
+		for score := 2; score <= maxCheckout; score++ {
+			if chance[darts][score] == 0 {
+				continue
+			}
+			// Follow the best targets, assuming each one is hit
+			out := Out{Score: score, Probability: chance[darts][score]}
+			for rest, left := score, darts; rest > 0; left-- {
+				target := beds[best[left][rest]]
+				out.Targets = append(out.Targets, target)
+				rest -= bedScore(target)
+			}
+			chart.outs[darts][score] = out
+		}
+	}
+	chart.applyOverrides(player.RouteOverrides)
+	return chart
+}
@@ -0,0 +1,65 @@
+package oh1
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+	"github.com/kregan77/dartbuddy/internal/model/notation"
+)
+
+func TestPlayerOutsWithoutSpreadFinishLikeTheStandardChart(t *testing.T) {
+	profile := model.NewPlayer("A", 100, model.TwentiesScoringPreference)
+	chart := NewPlayerOutChart(model.NewSeededSimulator(1), profile, 0.1)
+	standard := NewOutChart()
+	for darts := 1; darts <= maxCheckoutDarts; darts++ {
+		for score := 2; score <= maxCheckout; score++ {
+			out, want := chart.GetOutIn(score, darts), standard.GetOutIn(score, darts)
+			switch {
+			case want == nil && out != nil:
+				t.Errorf("%d in %d darts: got %s, want no out", score, darts, notation.FormatTurn(out.Targets))
+			case want != nil && out == nil:
+				t.Errorf("%d in %d darts: no out, want one like %s", score, darts, notation.FormatTurn(want.Targets))
+			case out != nil:
+				if err := ValidateRoute(score, out.Targets); err != nil || len(out.Targets) > darts {
+					t.Errorf("%d in %d darts: %s is not a checkout", score, darts, notation.FormatTurn(out.Targets))
+				}
+				if out.Probability < 0.99 {
+					t.Errorf("%d in %d darts: %s checks out with probability %v without spread",
+						score, darts, notation.FormatTurn(out.Targets), out.Probability)
+				}
+			}
+		}
+	}
+	if out := chart.GetOut(170); out == nil || notation.FormatTurn(out.Targets) != "T20 T20 DB" {
+		t.Errorf("170: got %v, want T20 T20 DB", out)
+	}
+}
+
+func TestPlayerOutOddsFallWithSpread(t *testing.T) {
+	profile := model.NewPlayer("A", 60, model.TwentiesScoringPreference)
+	spreads := []float64{5, 10, 20, 40}
+	var charts []*OutChart
+	for _, spread := range spreads {
+		charts = append(charts, NewPlayerOutChart(model.NewSeededSimulator(1), profile, spread))
+	}
+	probability := func(chart *OutChart, score, darts int) float64 {
+		if out := chart.GetOutIn(score, darts); out != nil {
+			return out.Probability
+		}
+		return 0
+	}
+	for _, score := range []int{2, 32, 40, 50, 61, 81, 100, 121, 141, 170} {
+		for darts := 1; darts <= maxCheckoutDarts; darts++ {
+			prev := math.Inf(1)
+			for i, chart := range charts {
+				p := probability(chart, score, darts)
+				if p > prev {
+					t.Errorf("%d in %d darts: probability rises from %.3f to %.3f at spread %g",
+						score, darts, prev, p, spreads[i])
+				}
+				prev = p
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package oh1
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+	"github.com/kregan77/dartbuddy/internal/model/notation"
+)
+
+// ansiClear moves the cursor home and clears the terminal
+const ansiClear = "\x1b[H\x1b[2J"
+
+// scoreboard redraws the board and an x01 scoreboard on a terminal after every turn
+type scoreboard struct {
+	w     io.Writer
+	g     *Game
+	darts []*model.DartResult // the current turn's darts
+	last  string              // what happened in the turn just played
+}
+
+// NewScoreboard returns an observer that clears the terminal w after every turn of g and
+// draws the board with that turn's darts beside the remaining scores, three dart averages
+// and suggested checkouts. The terminal must support ANSI escapes and 256 colours.
+func NewScoreboard(w io.Writer, g *Game) Observer {
+	return &scoreboard{w: w, g: g}
+}
+
+func (s *scoreboard) OnEvent(e Event) {
+	switch e := e.(type) {
+	case TurnStarted:
+		s.darts = s.darts[:0]
+	case DartThrown:
+		s.darts = append(s.darts, e.Result)
+	case TurnScored:
+		s.last = fmt.Sprintf("%s: %s  scored %d, %d left", e.Player, s.formatDarts(), e.Scored, e.Remaining)
+		s.draw()
+	case Bust:
+		s.last = fmt.Sprintf("%s: %s  BUST, back to %d", e.Player, s.formatDarts(), e.Score)
+		s.draw()
+	case LegWon:
+		s.last = fmt.Sprintf("%s: %s  wins in %d darts!", e.Player, s.formatDarts(), e.Darts)
+		s.draw()
+	}
+}
+
+// draw clears the terminal and redraws the board beside the scoreboard
+func (s *scoreboard) draw() {
+	board := s.g.Simulator.RenderANSI(model.ANSIOptions{Darts: s.darts})
+	table := s.table()
+
+	var b strings.Builder
+	b.WriteString(ansiClear)
+	for i := range max(len(board), len(table)) {
+		if i < len(board) {
+			b.WriteString(board[i])
+		}
+		if i < len(table) {
+			b.WriteString("   ")
+			b.WriteString(table[i])
+		}
+		b.WriteString("\n")
+	}
+	io.WriteString(s.w, b.String())
+}
+
+// table returns the scoreboard's lines
+func (s *scoreboard) table() []string {
+	g := s.g
+	lines := []string{
+		fmt.Sprintf("%d, turn %d", g.StartScore, g.Turn),
+		"",
+	}
+	won := false
+	for _, p := range g.Players {
+		if p.CurrentScore == 0 {
+			won = true
+		}
+	}
+	for i, p := range g.Players {
+		marker := " "
+		if i == g.CurrentPlayer && !won {
+			marker = "▶"
+		}
+		lines = append(lines, fmt.Sprintf("%s %-12s %4d   3DA %6.2f", marker, p.GetName(), p.CurrentScore, p.CurrentThreeDA()))
+		outs := g.GetOuts(p)
+		if out := outs.GetOut(p.CurrentScore); out != nil && p.CurrentScore > 0 {
+			lines = append(lines, "    checkout: "+notation.FormatTurn(out.Targets))
+		} else if setup := outs.GetSetupToFinish(p.CurrentScore, p.GetScoringPreference()); setup != nil {
+			lines = append(lines, fmt.Sprintf("    setup: %s, leaves %d", notation.FormatTurn(setup.Targets), setup.Leave))
+		}
+	}
+	return append(lines, "", s.last)
+}
+
+// formatDarts returns the current turn's darts in short form
+func (s *scoreboard) formatDarts() string {
+	darts := make([]model.DartTarget, len(s.darts))
+	for i, d := range s.darts {
+		darts[i] = d.DartTarget
+	}
+	return notation.FormatTurn(darts)
+}
@@ -0,0 +1,135 @@
+package oh1
+
+import (
+	"math"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+)
+
+// maxSetupScore is the highest score from which three darts can leave a checkout
+const maxSetupScore = maxCheckout + maxCheckoutDarts*60
+
+// Leave weights: a finish is always better than none, including a bogey number, and
+// otherwise fewer points left are better
+const (
+	costNoFinish     = costPerDart * (maxCheckoutDarts + 2) // at least one more full visit before a finish
+	costPerPointLeft = 1.0
+)
+
+// Setup is a planned scoring visit and the score it leaves if every dart hits
+type Setup struct {
+	Targets []model.DartTarget
+	Leave   int
+}
+
+// SetupPlanner chooses scoring darts when there is no checkout in hand. The player keeps
+// to their scoring bed while it leaves a finish, or while no finish can be left; otherwise
+// the planner aims to leave a finish on a preferred double for the next visit, steering clear
+// of the bogey numbers. Leaves are ranked by the cost of their best checkout under the
+// solver's policy.
+type SetupPlanner struct {
+	beds       []model.DartTarget
+	finishable [maxSetupScore + 1]bool // whether a score can be checked out in one visit
+	// cost[d][s] is the lowest cost of what d more darts can leave from s,
+	// not counting preferences for the scoring bed
+	cost [maxCheckoutDarts + 1][maxSetupScore + 1]float64
+}
+
+// NewSetupPlanner creates a planner ranking leaves with the solver's checkouts
+func NewSetupPlanner(solver *CheckoutSolver) *SetupPlanner {
+	sp := &SetupPlanner{beds: solver.beds}
+	for score := 2; score <= maxSetupScore; score++ {
+		sp.cost[0][score] = leaveCost(solver, score)
+		sp.finishable[score] = score <= maxCheckout && solver.GetBest(score) != nil
+	}
+	sp.cost[0][1] = math.Inf(1)
+	for darts := 1; darts <= maxCheckoutDarts; darts++ {
+		sp.cost[darts][1] = math.Inf(1)
+		for score := 2; score <= maxSetupScore; score++ {
+			sp.cost[darts][score] = math.Inf(1)
+			for _, t := range sp.beds {
+				if c, ok := sp.costAfter(t, score, darts, setupCost(t, false)); ok && c < sp.cost[darts][score] {
+					sp.cost[darts][score] = c
+				}
+			}
+		}
+	}
+	return sp
+}
+
+// leaveCost ranks a score left at the end of a visit by the cost of its best checkout
+// and then by the points still to score. Scores with no checkout, bogey numbers included,
+// rank after every finish and among themselves by points alone.
+func leaveCost(solver *CheckoutSolver, score int) float64 {
+	cost := costPerPointLeft * float64(score)
+	if out := solver.GetBest(score); out != nil {
+		return cost + solver.Policy.Cost(*out)
+	}
+	return cost + costNoFinish
+}
+
+// costAfter returns the cost of throwing a dart at t from score with darts in hand,
+// given the dart's own cost. A dart that busts is not allowed; one that checks out costs
+// nothing more.
+func (sp *SetupPlanner) costAfter(t model.DartTarget, score, darts int, dartCost float64) (float64, bool) {
+	rest := score - bedScore(t)
+	switch {
+	case rest == 0 && DoubleOut.Finishes(t):
+		return dartCost, true
+	case rest < 2:
+		return 0, false
+	}
+	return dartCost + sp.cost[darts-1][rest], true
+}
+
+// Plan returns the setup for the darts in hand from score. Every dart goes at the
+// preference's scoring bed while no finish can be left with the darts in hand, or while
+// keeping to that bed leaves one; otherwise the planner picks the darts that leave the
+// best finish, still favouring the scoring bed whenever it leaves as well as another.
+// Returns nil if every dart would bust.
+func (sp *SetupPlanner) Plan(score, darts int, preference model.ScoringPreference) *Setup {
+	scoring := preference.GetTarget()
+	setup := &Setup{Leave: score}
+	for ; darts > 0 && setup.Leave > 0; darts-- {
+		if sp.keepScoring(setup.Leave, darts, scoring) {
+			setup.Targets = append(setup.Targets, scoring)
+			setup.Leave -= bedScore(scoring)
+			continue
+		}
+		best, bestCost := model.DartTarget{}, math.Inf(1)
+		for _, t := range sp.beds {
+			dartCost := setupCost(t, false)
+			if t == scoring {
+				dartCost = 0
+			}
+			if c, ok := sp.costAfter(t, setup.Leave, darts, dartCost); ok && c < bestCost {
+				best, bestCost = t, c
+			}
+		}
+		if math.IsInf(bestCost, 1) {
+			return nil
+		}
+		setup.Targets = append(setup.Targets, best)
+		setup.Leave -= bedScore(best)
+	}
+	return setup
+}
+
+// keepScoring reports whether the darts in hand from score should all go at the scoring bed:
+// either no finish can be left with them, or hitting the scoring bed with each leaves one
+func (sp *SetupPlanner) keepScoring(score, darts int, scoring model.DartTarget) bool {
+	if score-darts*maxBedScore > maxCheckout {
+		return true
+	}
+	rest := score - darts*bedScore(scoring)
+	return rest >= 2 && rest <= maxSetupScore && sp.finishable[rest]
+}
+
+// GetTarget returns the first target of the setup from score, or the preference's
+// scoring bed if there is none
+func (sp *SetupPlanner) GetTarget(score, darts int, preference model.ScoringPreference) model.DartTarget {
+	if setup := sp.Plan(score, darts, preference); setup != nil && len(setup.Targets) > 0 {
+		return setup.Targets[0]
+	}
+	return preference.GetTarget()
+}
@@ -0,0 +1,78 @@
+package oh1
+
+import (
+	"testing"
+
+	"github.com/kregan77/dartbuddy/internal/model"
+	"github.com/kregan77/dartbuddy/internal/model/notation"
+)
+
+func TestPlanSetup(t *testing.T) {
+	chart := NewOutChart()
+	tests := []struct {
+		name       string
+		score      int
+		darts      int
+		preference model.ScoringPreference
+		want       string
+		leave      int
+	}{
+		{"far from a finish", 401, 3, model.TwentiesScoringPreference, "T20 T20 T20", 221},
+		{"nineteens far from a finish", 401, 3, model.NinteensScoringPreference, "T19 T19 T19", 230},
+		{"nineteens leaving a finish", 300, 3, model.NinteensScoringPreference, "T19 T19 T19", 129},
+		{"bull leaving a finish", 300, 3, model.BullScoringPreference, "DB DB DB", 150},
+		{"eighteens leaving a finish", 250, 3, model.EighteensScoringPreference, "T18 T18 T18", 88},
+		{"fewest points when no finish can be left", 219, 1, model.TwentiesScoringPreference, "T20", 159},
+		{"no bust on 180", 180, 3, model.TwentiesScoringPreference, "T20 T20 S20", 40},
+		{"no bogey from 222", 222, 2, model.TwentiesScoringPreference, "T20 T20", 102},
+	}
+	for _, tt := range tests {
+		setup := chart.GetSetup(tt.score, tt.darts, tt.preference)
+		if setup == nil {
+			t.Errorf("%s: no setup from %d", tt.name, tt.score)
+			continue
+		}
+		if got := notation.FormatTurn(setup.Targets); got != tt.want || setup.Leave != tt.leave {
+			t.Errorf("%s: %d in %d darts: got %s leaving %d, want %s leaving %d",
+				tt.name, tt.score, tt.darts, got, setup.Leave, tt.want, tt.leave)
+		}
+	}
+}
+
+func TestPlanAvoidsBogeys(t *testing.T) {
+	chart := NewOutChart()
+	for score := 172; score <= 290; score++ {
+		for darts := 1; darts <= maxCheckoutDarts; darts++ {
+			setup := chart.GetSetup(score, darts, model.TwentiesScoringPreference)
+			if setup == nil || chart.GetOut(setup.Leave) != nil {
+				continue
+			}
+			// A leave with no finish is only acceptable if no darts leave one
+			if targets := findFinishingLeave(chart, score, darts); targets != nil {
+				t.Errorf("%d in %d darts: %s leaves %d, but %s leaves a finish",
+					score, darts, notation.FormatTurn(setup.Targets), setup.Leave, notation.FormatTurn(targets))
+			}
+		}
+	}
+}
+
+// findFinishingLeave returns darts that score from score down to a checkout without busting,
+// or nil if there are none
+func findFinishingLeave(chart *OutChart, score, darts int) []model.DartTarget {
+	for _, bed := range chart.planner.beds {
+		rest := score - bedScore(bed)
+		if rest < 2 {
+			continue
+		}
+		if darts == 1 {
+			if chart.GetOut(rest) != nil {
+				return []model.DartTarget{bed}
+			}
+			continue
+		}
+		if targets := findFinishingLeave(chart, rest, darts-1); targets != nil {
+			return append([]model.DartTarget{bed}, targets...)
+		}
+	}
+	return nil
+}
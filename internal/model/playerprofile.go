@@ -1,8 +1,6 @@
 package model
 
 import (
-	"fmt"
-
 	"github.com/google/uuid"
 )
 
@@ -18,14 +16,70 @@ type ScoringPreference int
 const (
 	TwentiesScoringPreference ScoringPreference = iota
 	NinteensScoringPreference
+	EighteensScoringPreference
+	BullScoringPreference
+	CoverScoringPreference // the 20s, switching to the 19s when earlier darts block the treble
 )
 
+// GetTarget returns the target a player with this preference aims at when scoring
+func (sp ScoringPreference) GetTarget() DartTarget {
+	switch sp {
+	case NinteensScoringPreference:
+		return DartTarget{Multiplier: Triple, Number: Nineteen}
+	case EighteensScoringPreference:
+		return DartTarget{Multiplier: Triple, Number: Eighteen}
+	case BullScoringPreference:
+		return DartTarget{Multiplier: Double, Number: Bullseye}
+	default:
+		return DartTarget{Multiplier: Triple, Number: Twenty}
+	}
+}
+
+// Resolve returns the preference to score with given which beds are blocked by darts
+// already in the board: covering switches from the 20s to the 19s when T20 is blocked.
+// Other preferences are returned unchanged.
+func (sp ScoringPreference) Resolve(blocked func(DartTarget) bool) ScoringPreference {
+	if sp == CoverScoringPreference {
+		if blocked(sp.GetTarget()) {
+			return NinteensScoringPreference
+		}
+		return TwentiesScoringPreference
+	}
+	return sp
+}
+
+// ScoringPreferenceByName returns the preference with the given name
+// ("twenties", "nineteens", "eighteens", "bull" or "cover")
+func ScoringPreferenceByName(name string) (ScoringPreference, bool) {
+	switch name {
+	case "", "twenties":
+		return TwentiesScoringPreference, true
+	case "nineteens":
+		return NinteensScoringPreference, true
+	case "eighteens":
+		return EighteensScoringPreference, true
+	case "bull":
+		return BullScoringPreference, true
+	case "cover":
+		return CoverScoringPreference, true
+	default:
+		return 0, false
+	}
+}
+
 type PlayerProfile struct {
 	ID                uuid.UUID
 	Name              string
 	PlayerType        PlayerType
 	ThreeDA           float64 // x01 Three Dart Average - currently only applicable to simulated players.
 	ScoringPreference ScoringPreference
+	ThrowModel        ThrowModel             // how the player's darts disperse; nil means isotropic Gaussian
+	AimBias           AimBias                // systematic drift from the aim point; zero means none
+	OptimalAim        bool                   // simulated players aim at solved optimal points rather than bed centres
+	TargetAccuracy    map[DartTarget]float64 // per-target spread multiplier: below 1 is better than usual
+	ReplayHistory     *ThrowHistory          // if set, simulated darts are sampled from this real player's history
+	PreferredDoubles  []int                  // favourite finishing doubles, best first (25 for the bull); nil uses the standard order
+	RouteOverrides    map[int][]DartTarget   // the player's own checkouts for particular scores
 }
 
 func NewPlayer(name string, threeDA float64, scoringPreference ScoringPreference) *PlayerProfile {
@@ -52,3 +106,33 @@ func (p *PlayerProfile) GetThreeDA() float64 {
 func (p *PlayerProfile) GetScoringPreference() ScoringPreference {
 	return p.ScoringPreference
 }
+
+// GetThrowModel returns the player's throw dispersion model, defaulting to an isotropic Gaussian
+func (p *PlayerProfile) GetThrowModel() ThrowModel {
+	if p.ThrowModel == nil {
+		return GaussianThrowModel{}
+	}
+	return p.ThrowModel
+}
+
+// HasCheckoutPreferences reports whether the player has their own doubles or routes
+func (p *PlayerProfile) HasCheckoutPreferences() bool {
+	return len(p.PreferredDoubles) > 0 || len(p.RouteOverrides) > 0
+}
+
+// GetTargetSpread returns the player's spread when aiming at target, applying any
+// per-target accuracy over their usual spread
+func (p *PlayerProfile) GetTargetSpread(target DartTarget, spread float64) float64 {
+	if factor, ok := p.TargetAccuracy[target]; ok && factor > 0 {
+		return spread * factor
+	}
+	return spread
+}
+
+// SetTargetAccuracy sets the spread multiplier used when aiming at target
+func (p *PlayerProfile) SetTargetAccuracy(target DartTarget, factor float64) {
+	if p.TargetAccuracy == nil {
+		p.TargetAccuracy = make(map[DartTarget]float64)
+	}
+	p.TargetAccuracy[target] = factor
+}
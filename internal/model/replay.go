@@ -0,0 +1,126 @@
+package model
+
+import "math"
+
+// replayMultiplierPenalty is added (mm) to the distance between two targets in different rings,
+// so a replay bot prefers borrowing darts thrown at the same kind of bed
+const replayMultiplierPenalty = 100.0
+
+// ThrowHistory is a real player's recorded darts, grouped by what they aimed at
+type ThrowHistory struct {
+	byTarget map[DartTarget][]ThrowRecord
+	count    int
+}
+
+// NewThrowHistory creates a history from recorded darts
+func NewThrowHistory(records ...ThrowRecord) *ThrowHistory {
+	h := &ThrowHistory{byTarget: make(map[DartTarget][]ThrowRecord)}
+	for _, r := range records {
+		h.Add(r)
+	}
+	return h
+}
+
+// Add records a dart
+func (h *ThrowHistory) Add(record ThrowRecord) {
+	h.byTarget[record.Target] = append(h.byTarget[record.Target], record)
+	h.count++
+}
+
+// Len returns the number of recorded darts
+func (h *ThrowHistory) Len() int {
+	return h.count
+}
+
+// GetRecords returns the darts recorded when aiming at target
+func (h *ThrowHistory) GetRecords(target DartTarget) []ThrowRecord {
+	return h.byTarget[target]
+}
+
+// ReplayDart throws a dart at target by sampling what the recorded player actually did
+// when aiming there. If they never aimed at target, a dart aimed at the most similar
+// target is borrowed and moved across: by its landing offset when known, otherwise by
+// keeping the hit bed's position relative to its target.
+// Returns nil if the history is empty.
+func (s *Simulator) ReplayDart(history *ThrowHistory, target DartTarget) *DartResult {
+	if history == nil || history.Len() == 0 {
+		return nil
+	}
+
+	source := s.findSimilarTarget(history, target)
+	records := history.byTarget[source]
+	record := records[s.rng.Intn(len(records))]
+
+	aimX, aimY := s.getTargetPoint(target)
+	aim := NewBoardPoint(aimX, aimY)
+	if record.Landing != nil {
+		sourceX, sourceY := s.getTargetPoint(source)
+		result := s.ScorePoint(aimX+record.Landing.X-sourceX, aimY+record.Landing.Y-sourceY)
+		result.Aim = &aim
+		return &result
+	}
+
+	hit := s.shiftTarget(record.Result, source, target)
+	return &DartResult{
+		DartTarget: hit,
+		Score:      hit.Number * int(hit.Multiplier),
+		Aim:        &aim,
+	}
+}
+
+// findSimilarTarget returns target if the history has darts aimed at it, otherwise the
+// recorded target whose aim point is closest
+func (s *Simulator) findSimilarTarget(history *ThrowHistory, target DartTarget) DartTarget {
+	if len(history.byTarget[target]) > 0 {
+		return target
+	}
+
+	targetX, targetY := s.getTargetPoint(target)
+	best := target
+	bestDistance := math.Inf(1)
+	for candidate, records := range history.byTarget {
+		if len(records) == 0 {
+			continue
+		}
+		x, y := s.getTargetPoint(candidate)
+		distance := math.Hypot(x-targetX, y-targetY)
+		if candidate.Multiplier != target.Multiplier {
+			distance += replayMultiplierPenalty
+		}
+		// Break ties deterministically, since map iteration order is random
+		if distance < bestDistance || (distance == bestDistance && targetLess(candidate, best)) {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+// targetLess orders targets by multiplier then number
+func targetLess(a, b DartTarget) bool {
+	if a.Multiplier != b.Multiplier {
+		return a.Multiplier < b.Multiplier
+	}
+	return a.Number < b.Number
+}
+
+// shiftTarget moves a bed hit while aiming at from so that it keeps the same position
+// relative to to: a dart one segment clockwise of T20 becomes one segment clockwise of T19
+func (s *Simulator) shiftTarget(hit, from, to DartTarget) DartTarget {
+	if hit.Multiplier == Miss || hit.Number == Bullseye || from.Number == Bullseye || to.Number == Bullseye {
+		return hit
+	}
+	offset := boardIndex(hit.Number) - boardIndex(from.Number)
+	index := (boardIndex(to.Number) + offset + len(Board)) % len(Board)
+	return DartTarget{Multiplier: hit.Multiplier, Number: Board[index]}
+}
+
+// boardIndex returns the position of a number clockwise from the 20
+func boardIndex(number int) int {
+	for i, n := range Board {
+		if n == number {
+			return i
+		}
+	}
+	return 0
+}
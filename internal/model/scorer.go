@@ -0,0 +1,48 @@
+package model
+
+import "math"
+
+// Scorer scores darts from landing coordinates reported by an external source
+// such as an autoscoring camera, using the same geometry as the simulator.
+type Scorer struct {
+	sim        *Simulator
+	Rotation   float64 // radians clockwise from the top of the input frame to the centre of the 20
+	Normalised bool    // coordinates are fractions of the board radius rather than mm
+	InvertY    bool    // y grows downwards in the input, as in image coordinates
+}
+
+// NewScorer creates a scorer for the given board geometry.
+// By default coordinates are in mm from the centre, x right and y up, with the 20 straight up.
+func NewScorer(spec *BoardSpec) *Scorer {
+	return &Scorer{sim: NewBoardSimulator(spec, 0)}
+}
+
+// Score returns the result of a dart that landed at (x, y) in the scorer's coordinate system.
+// The result's Landing is in board coordinates (mm, 20 straight up).
+func (sc *Scorer) Score(x, y float64) DartResult {
+	bx, by := sc.ToBoard(x, y)
+	return sc.sim.ScorePoint(bx, by)
+}
+
+// ToBoard converts input coordinates into board coordinates in mm, x right, y up and 20 at the top
+func (sc *Scorer) ToBoard(x, y float64) (float64, float64) {
+	if sc.Normalised {
+		x *= sc.sim.spec.BoardRadius
+		y *= sc.sim.spec.BoardRadius
+	}
+	if sc.InvertY {
+		y = -y
+	}
+	// Turn the frame anticlockwise so the 20 is straight up
+	sin, cos := math.Sincos(sc.Rotation)
+	return x*cos - y*sin, x*sin + y*cos
+}
+
+// ScorePoint returns the result of a dart landing at the Cartesian point (x, y) in mm,
+// with x to the right, y up and the 20 straight up
+func (s *Simulator) ScorePoint(x, y float64) DartResult {
+	landing := NewBoardPoint(x, y)
+	result := s.determineHit(landing.Radius, landing.Angle)
+	result.Landing = landing
+	return result
+}
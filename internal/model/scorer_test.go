@@ -0,0 +1,46 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScorerToBoard(t *testing.T) {
+	s20 := DartTarget{Multiplier: Single, Number: Twenty}
+	tests := []struct {
+		name       string
+		rotation   float64 // degrees
+		normalised bool
+		invertY    bool
+		x, y       float64
+		wantX      float64
+		wantY      float64
+		want       DartTarget
+	}{
+		{"board coordinates", 0, false, false, 0, 130, 0, 130, s20},
+		{"board coordinates to the right", 0, false, false, 130, 0, 130, 0, DartTarget{Multiplier: Single, Number: 6}},
+		{"20 to the right", 90, false, false, 130, 0, 0, 130, s20},
+		{"20 to the left", -90, false, false, -130, 0, 0, 130, s20},
+		{"20 at the bottom", 180, false, false, 0, -130, 0, 130, s20},
+		{"20 to the right, 11 up", 90, false, false, 0, 130, -130, 0, DartTarget{Multiplier: Single, Number: 11}},
+		{"y down", 0, false, true, 0, -130, 0, 130, s20},
+		{"y down at the right", 0, false, true, 130, 15, 130, -15, DartTarget{Multiplier: Single, Number: 6}},
+		{"normalised", 0, true, false, 0, 0.99, 0, 0.99 * BoardRadius, DartTarget{Multiplier: Double, Number: Twenty}},
+		{"normalised bull", 0, true, false, 0.01, 0, 0.01 * BoardRadius, 0, DartTarget{Multiplier: Double, Number: Bullseye}},
+		{"normalised, y down, 20 at the bottom", 180, true, true, 0, 0.5, 0, 0.5 * BoardRadius, s20},
+	}
+	for _, tt := range tests {
+		sc := NewScorer(SteelTipBoardSpec())
+		sc.Rotation = tt.rotation * math.Pi / 180
+		sc.Normalised = tt.normalised
+		sc.InvertY = tt.invertY
+
+		x, y := sc.ToBoard(tt.x, tt.y)
+		if math.Abs(x-tt.wantX) > 1e-9 || math.Abs(y-tt.wantY) > 1e-9 {
+			t.Errorf("%s: ToBoard(%v, %v) = (%v, %v), want (%v, %v)", tt.name, tt.x, tt.y, x, y, tt.wantX, tt.wantY)
+		}
+		if got := sc.Score(tt.x, tt.y); got.DartTarget != tt.want {
+			t.Errorf("%s: Score(%v, %v) = %v, want %v", tt.name, tt.x, tt.y, got.DartTarget, tt.want)
+		}
+	}
+}
@@ -30,6 +30,8 @@ func (m Multiplier) String() string {
 		return "Double"
 	case Triple:
 		return "Triple"
+	case Quadruple:
+		return "Quadruple"
 	default:
 		return "Unknown"
 	}
@@ -40,6 +42,8 @@ const (
 	Single Multiplier = 1
 	Double Multiplier = 2
 	Triple Multiplier = 3
+	// Quadruple only exists on quadro boards
+	Quadruple Multiplier = 4
 )
 
 // DartTarget represents a single dart target with multiplier and number
@@ -60,148 +64,237 @@ func (t *DartTarget) String() string {
 	return fmt.Sprintf("%s %d", t.GetMultiplier().String(), t.GetNumber())
 }
 
+// BoardPoint is a position on the board in mm, in both Cartesian and polar form.
+// X is horizontal (positive to the right), Y is vertical (positive up) and Angle is
+// measured in radians clockwise from straight up, where 20 is.
+type BoardPoint struct {
+	X      float64
+	Y      float64
+	Radius float64
+	Angle  float64
+}
+
+// NewBoardPoint creates a BoardPoint from Cartesian coordinates
+func NewBoardPoint(x, y float64) BoardPoint {
+	return BoardPoint{
+		X:      x,
+		Y:      y,
+		Radius: math.Sqrt(x*x + y*y),
+		Angle:  math.Atan2(x, y),
+	}
+}
+
+// DistanceTo returns the distance in mm between two points
+func (p BoardPoint) DistanceTo(other BoardPoint) float64 {
+	return math.Hypot(p.X-other.X, p.Y-other.Y)
+}
+
 // DartResult represents the outcome of a single dart throw
 type DartResult struct {
 	DartTarget
-	Score int
+	Score     int
+	BounceOut bool        // the dart struck a wire or another dart and fell out
+	Landing   BoardPoint  // where the dart struck the board
+	Aim       *BoardPoint // where the thrower intended to aim, before any bias; nil if unknown
+}
+
+// MissDistance returns how far (mm) the dart landed from where it was aimed,
+// and false if the aim point is unknown
+func (d *DartResult) MissDistance() (float64, bool) {
+	if d.Aim == nil {
+		return 0, false
+	}
+	return d.Landing.DistanceTo(*d.Aim), true
 }
 
 func (d *DartResult) String() string {
+	if d.BounceOut {
+		return "Bounce out(0)"
+	}
 	return fmt.Sprintf("%s %d(%d)", d.GetMultiplier().String(), d.GetNumber(), d.Score)
 }
 
 // Simulator handles dart throw simulation with cached dartboard geometry
 type Simulator struct {
-	angleMap map[int]float64 // maps number to center angle in radians
-	rng      *rand.Rand
+	tables    *boardTables
+	rng       *rand.Rand
+	seed      int64
+	spec      *BoardSpec
+	wires     WireConfig
+	turnDarts [][2]float64 // landing points of darts already in the board this turn
+	inTurn    bool         // between StartTurn and EndTurn, while landing points are recorded
 }
 
-// NewSimulator creates and initializes a new dart simulator
+// NewSimulator creates and initializes a new steel-tip dart simulator with a random seed
 func NewSimulator() *Simulator {
-	sim := &Simulator{
-		angleMap: make(map[int]float64),
-		rng:      rand.New(rand.NewSource(rand.Int63())),
-	}
-	sim.initializeDartboard()
-	return sim
+	return NewSeededSimulator(rand.Int63())
 }
 
-// initializeDartboard pre-computes and caches angles for all numbers
-func (s *Simulator) initializeDartboard() {
-	// Each segment is 18 degrees (2π/20 radians)
-	segmentAngle := 2.0 * math.Pi / 20.0
+// NewSeededSimulator creates a steel-tip simulator whose throws are fully determined by seed.
+// Two simulators built from the same seed produce the same sequence of results
+// for the same sequence of ThrowDart calls.
+func NewSeededSimulator(seed int64) *Simulator {
+	return NewBoardSimulator(SteelTipBoardSpec(), seed)
+}
 
-	for i, number := range Board {
-		// Calculate center angle for this segment
-		// 0 radians is at top where 20 is located
-		s.angleMap[number] = float64(i) * segmentAngle
+// NewBoardSimulator creates a seeded simulator for the given board geometry.
+// The spec must not be modified while the simulator is in use.
+func NewBoardSimulator(spec *BoardSpec, seed int64) *Simulator {
+	return &Simulator{
+		tables:    newBoardTables(spec),
+		rng:       rand.New(rand.NewSource(seed)),
+		seed:      seed,
+		spec:      spec,
+		turnDarts: make([][2]float64, 0, 3),
 	}
+}
 
-	// Bullseye doesn't have a specific angle
-	s.angleMap[Bullseye] = 0.0
+// GetBoardSpec returns the board geometry the simulator was created with
+func (s *Simulator) GetBoardSpec() *BoardSpec {
+	return s.spec
 }
 
-// getAngleForNumber returns the center angle (in radians) for a given number
-func (s *Simulator) getAngleForNumber(number int) float64 {
-	if angle, ok := s.angleMap[number]; ok {
-		return angle
-	}
-	return 0.0 // Default to top if number not found
+// GetSeed returns the seed the simulator was created with
+func (s *Simulator) GetSeed() int64 {
+	return s.seed
 }
 
-// getNumberFromAngle returns the number at a given angle (in radians)
-func (s *Simulator) getNumberFromAngle(angle float64) int {
-	// Normalize angle to [0, 2π)
-	for angle < 0 {
-		angle += 2.0 * math.Pi
-	}
-	for angle >= 2.0*math.Pi {
-		angle -= 2.0 * math.Pi
-	}
+// Reset rewinds the simulator to the start of its seeded sequence and ends any turn in progress
+func (s *Simulator) Reset() {
+	s.rng.Seed(s.seed)
+	s.EndTurn()
+}
 
-	// Each segment is 18 degrees (2π/20 radians)
-	segmentAngle := 2.0 * math.Pi / 20.0
+// Reseed restarts the simulator on a new seeded sequence, so one simulator can be reused
+// for many independent reproducible runs
+func (s *Simulator) Reseed(seed int64) {
+	s.seed = seed
+	s.rng.Seed(seed)
+	s.EndTurn()
+}
 
-	// Find which segment this angle falls into
-	segmentIndex := int(math.Round(angle/segmentAngle)) % 20
+// ThrowDart simulates a single dart throw using 2D Gaussian distribution
+func (s *Simulator) ThrowDart(target DartTarget, spread float64) *DartResult {
+	return s.ThrowDartWithModel(target, spread, GaussianThrowModel{})
+}
 
-	return Board[segmentIndex]
+// ThrowDartWithModel simulates a single dart throw, sampling the miss from the given throw model.
+func (s *Simulator) ThrowDartWithModel(target DartTarget, spread float64, model ThrowModel) *DartResult {
+	// Get target coordinates
+	targetX, targetY := s.getTargetPoint(target)
+	return s.ThrowAt(targetX, targetY, spread, model)
 }
 
-// getTargetRadius returns the target radius for a given multiplier
-func (s *Simulator) getTargetRadius(multiplier Multiplier) float64 {
-	switch multiplier {
-	case Triple:
-		return (TripleInnerRadius + TripleOuterRadius) / 2.0
-	case Double:
-		return (DoubleInnerRadius + DoubleOuterRadius) / 2.0
-	case Single:
-		// Aim at the larger single area (between triple and double)
-		return (TripleOuterRadius + DoubleInnerRadius) / 2.0
+// ThrowDartForPlayer simulates a dart thrown by the given player at a target,
+// applying the player's accuracy on that target, aim bias and throw model.
+func (s *Simulator) ThrowDartForPlayer(player *PlayerProfile, target DartTarget, spread float64) *DartResult {
+	targetX, targetY := s.getTargetPoint(target)
+	biasX, biasY := player.AimBias.Offset(target)
+	spread = player.GetTargetSpread(target, spread)
+	return withAim(s.ThrowPoint(targetX+biasX, targetY+biasY, spread, player.GetThrowModel()), targetX, targetY)
+}
+
+// ThrowAtForPlayer simulates a dart thrown by the given player at the Cartesian point (aimX, aimY),
+// applying the player's accuracy and aim bias for the bed at that point and their throw model.
+func (s *Simulator) ThrowAtForPlayer(player *PlayerProfile, aimX, aimY, spread float64) *DartResult {
+	bed := s.GetTargetAt(aimX, aimY)
+	biasX, biasY := player.AimBias.Offset(bed)
+	spread = player.GetTargetSpread(bed, spread)
+	return withAim(s.ThrowPoint(aimX+biasX, aimY+biasY, spread, player.GetThrowModel()), aimX, aimY)
+}
+
+// ThrowAt simulates a dart aimed at the Cartesian point (aimX, aimY) in mm.
+// A nil model falls back to the isotropic Gaussian.
+func (s *Simulator) ThrowAt(aimX, aimY, spread float64, model ThrowModel) *DartResult {
+	return withAim(s.ThrowPoint(aimX, aimY, spread, model), aimX, aimY)
+}
+
+// Throw is ThrowDartWithModel without the allocations: the result is returned by value
+// and its Aim is left nil. Use it in loops that throw many darts, such as Monte Carlo runs;
+// outside a turn it never allocates.
+func (s *Simulator) Throw(target DartTarget, spread float64, model ThrowModel) DartResult {
+	targetX, targetY := s.getTargetPoint(target)
+	return s.ThrowPoint(targetX, targetY, spread, model)
+}
+
+// ThrowPoint is ThrowAt without the allocations: the result is returned by value
+// and its Aim is left nil. A nil model falls back to the isotropic Gaussian.
+func (s *Simulator) ThrowPoint(aimX, aimY, spread float64, model ThrowModel) DartResult {
+	if model == nil {
+		model = GaussianThrowModel{}
+	}
+
+	// Sample the miss around the aim point
+	dx, dy := model.Sample(s.rng, spread)
+	hitX := aimX + dx
+	hitY := aimY + dy
+
+	// Earlier darts in the board can block or deflect this one
+	hitX, hitY, bounced := s.applyShadow(hitX, hitY)
+
+	// Convert back to polar coordinates
+	landing := NewBoardPoint(hitX, hitY)
+
+	var result DartResult
+	switch {
+	case bounced, s.hitsWire(landing.Radius, landing.Angle) && s.rng.Float64() < s.wires.BounceOutChance:
+		result = bounceOut()
 	default:
-		return (TripleInnerRadius + TripleOuterRadius) / 2.0
+		// Determine what was hit
+		result = s.determineHit(landing.Radius, landing.Angle)
+		if s.inTurn && result.GetMultiplier() != Miss {
+			s.turnDarts = append(s.turnDarts, [2]float64{hitX, hitY})
+		}
 	}
+	result.Landing = landing
+	return result
 }
 
-// ThrowDart simulates a single dart throw using 2D Gaussian distribution
-func (s *Simulator) ThrowDart(target DartTarget, spread float64) *DartResult {
-	// Special case: aiming at bullseye
-	if target.Number == Bullseye {
-		// Aim at center (radius 0)
-		hitX := s.rng.NormFloat64() * spread
-		hitY := s.rng.NormFloat64() * spread
-		hitRadius := math.Sqrt(hitX*hitX + hitY*hitY)
-		hitAngle := math.Atan2(hitX, hitY)
-		return s.determineHit(hitRadius, hitAngle)
-	}
+// aimedResult keeps a result and its aim point in one allocation
+type aimedResult struct {
+	result DartResult
+	aim    BoardPoint
+}
 
-	// Get target coordinates
-	targetAngle := s.getAngleForNumber(target.Number)
-	targetRadius := s.getTargetRadius(target.Multiplier)
+// withAim moves a result to the heap with its Aim set to (aimX, aimY)
+func withAim(result DartResult, aimX, aimY float64) *DartResult {
+	r := &aimedResult{result: result, aim: NewBoardPoint(aimX, aimY)}
+	r.result.Aim = &r.aim
+	return &r.result
+}
 
-	// Convert to Cartesian coordinates
-	targetX := targetRadius * math.Sin(targetAngle)
-	targetY := targetRadius * math.Cos(targetAngle)
+// getTargetPoint returns the Cartesian aim point (in mm) for a target.
+// x is horizontal and y is vertical, with 20 straight up.
+func (s *Simulator) getTargetPoint(target DartTarget) (float64, float64) {
+	// Special case: aiming at bullseye means aiming at the centre
+	if target.Number == Bullseye {
+		return 0, 0
+	}
+	if target.Multiplier >= Miss && target.Multiplier <= Quadruple && target.Number >= 0 && target.Number <= Twenty {
+		p := s.tables.targets[target.Multiplier][target.Number]
+		return p[0], p[1]
+	}
 
-	// Sample from 2D Gaussian distribution
-	hitX := targetX + s.rng.NormFloat64()*spread
-	hitY := targetY + s.rng.NormFloat64()*spread
+	// Not a bed on the board; aim at the top as if it were the 20
+	radius := s.tables.targetRadius(s.spec, target.Multiplier)
+	return 0, radius
+}
 
-	// Convert back to polar coordinates
-	hitRadius := math.Sqrt(hitX*hitX + hitY*hitY)
-	hitAngle := math.Atan2(hitX, hitY)
+// GetTargetAt returns the bed at the Cartesian point (x, y) in mm
+func (s *Simulator) GetTargetAt(x, y float64) DartTarget {
+	return s.determineHit(math.Sqrt(x*x+y*y), math.Atan2(x, y)).DartTarget
+}
 
-	// Determine what was hit
-	return s.determineHit(hitRadius, hitAngle)
+// scoreAt returns the score of a dart landing at the Cartesian point (x, y) in mm
+func (s *Simulator) scoreAt(x, y float64) int {
+	return s.determineHit(math.Sqrt(x*x+y*y), math.Atan2(x, y)).Score
 }
 
 // determineHit determines what segment was hit based on radius and angle
-func (s *Simulator) determineHit(radius, angle float64) *DartResult {
-	var number int
-	var multiplier Multiplier
-
-	// Check radial distance first
-	if radius <= DoubleBullRadius {
-		// Double bull (50 points)
-		return &DartResult{
-			DartTarget: DartTarget{
-				Number:     Bullseye,
-				Multiplier: Double,
-			},
-			Score: 50,
-		}
-	} else if radius <= SingleBullRadius {
-		// Single bull (25 points)
-		return &DartResult{
-			DartTarget: DartTarget{
-				Number:     Bullseye,
-				Multiplier: Single,
-			},
-			Score: 25,
-		}
-	} else if radius > BoardRadius {
+func (s *Simulator) determineHit(radius, angle float64) DartResult {
+	if radius > s.spec.BoardRadius {
 		// Miss
-		return &DartResult{
+		return DartResult{
 			DartTarget: DartTarget{
 				Number:     0,
 				Multiplier: Miss,
@@ -210,50 +303,25 @@ func (s *Simulator) determineHit(radius, angle float64) *DartResult {
 		}
 	}
 
-	// Determine number from angle
-	number = s.getNumberFromAngle(angle)
-
-	// Determine multiplier from radius
-	if radius >= DoubleInnerRadius && radius <= DoubleOuterRadius {
-		multiplier = Double
-	} else if radius >= TripleInnerRadius && radius <= TripleOuterRadius {
-		multiplier = Triple
-	} else {
-		multiplier = Single
+	bed := s.tables.bedAt(s.spec, radius)
+	if bed.bull {
+		// Double bull (50 points) or single bull (25 points)
+		return DartResult{
+			DartTarget: DartTarget{
+				Number:     Bullseye,
+				Multiplier: bed.multiplier,
+			},
+			Score: Bullseye * int(bed.multiplier),
+		}
 	}
 
-	score := number * int(multiplier)
-
-	return &DartResult{
+	// Determine number from angle
+	number := s.tables.numberAt(angle)
+	return DartResult{
 		DartTarget: DartTarget{
 			Number:     number,
-			Multiplier: multiplier,
+			Multiplier: bed.multiplier,
 		},
-		Score: score,
+		Score: number * int(bed.multiplier),
 	}
 }
-
-// calculateSpread converts a 3DA to a standard deviation (spread) in mm
-func (s *Simulator) calculateSpread(threeDA float64) float64 {
-	// Professional players (90+ 3DA) should have tight grouping (~10-15mm)
-	// Intermediate players (60 3DA) should have moderate spread (~20-25mm)
-	// Beginners (30 3DA) should have wide spread (~35-40mm)
-
-	// Using an inverse relationship: spread = k / (threeDA + offset)
-	// This formula produces realistic spreads relative to the 8mm triple ring width
-	const k = 1800.0
-	const offset = 20.0
-
-	spread := k / (threeDA + offset)
-
-	// Clamp to reasonable values (5mm to 50mm)
-	if spread < 5.0 {
-		spread = 5.0
-	}
-	if spread > 50.0 {
-		spread = 50.0
-	}
-
-	fmt.Printf("Calculated spread for 3DA %.2f is %.2f mm\n", threeDA, spread)
-	return spread
-}
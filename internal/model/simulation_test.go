@@ -0,0 +1,123 @@
+package model
+
+import "testing"
+
+var benchResult DartResult
+
+func TestThrowOutsideTurnDoesNotAllocate(t *testing.T) {
+	sim := NewSeededSimulator(1)
+	sim.SetWireConfig(StandardWireConfig())
+	target := DartTarget{Multiplier: Triple, Number: Twenty}
+	allocs := testing.AllocsPerRun(10000, func() {
+		benchResult = sim.Throw(target, 20, GaussianThrowModel{})
+	})
+	if allocs != 0 {
+		t.Errorf("Throw outside a turn made %v allocations per dart, want 0", allocs)
+	}
+	if len(sim.turnDarts) != 0 {
+		t.Errorf("Throw outside a turn recorded %d darts", len(sim.turnDarts))
+	}
+}
+
+func TestTurnRecordsDarts(t *testing.T) {
+	sim := NewSeededSimulator(1)
+	target := DartTarget{Multiplier: Triple, Number: Twenty}
+	sim.StartTurn()
+	for range 3 {
+		sim.Throw(target, 1, GaussianThrowModel{})
+	}
+	if !sim.IsBlocked(target) {
+		t.Error("T20 is not blocked after three darts in it")
+	}
+	if sim.IsBlocked(DartTarget{Multiplier: Triple, Number: 19}) {
+		t.Error("T19 is blocked by darts in T20")
+	}
+	sim.EndTurn()
+	if sim.IsBlocked(target) {
+		t.Error("T20 is still blocked after the turn ended")
+	}
+	sim.StartTurn()
+	sim.Throw(target, 1, GaussianThrowModel{})
+	sim.Reset()
+	if sim.IsBlocked(target) {
+		t.Error("T20 is still blocked after Reset")
+	}
+}
+
+func BenchmarkThrowDart(b *testing.B) {
+	sim := NewSeededSimulator(1)
+	target := DartTarget{Multiplier: Triple, Number: Twenty}
+	b.ReportAllocs()
+	for b.Loop() {
+		benchResult = *sim.ThrowDart(target, 20)
+	}
+}
+
+func BenchmarkThrowOutsideTurn(b *testing.B) {
+	sim := NewSeededSimulator(1)
+	target := DartTarget{Multiplier: Triple, Number: Twenty}
+	b.ReportAllocs()
+	for b.Loop() {
+		benchResult = sim.Throw(target, 20, GaussianThrowModel{})
+	}
+	if allocs := testing.AllocsPerRun(100, func() { benchResult = sim.Throw(target, 20, GaussianThrowModel{}) }); allocs != 0 {
+		b.Fatalf("Throw outside a turn made %v allocations per dart, want 0", allocs)
+	}
+}
+
+func BenchmarkThrow(b *testing.B) {
+	sim := NewSeededSimulator(1)
+	target := DartTarget{Multiplier: Triple, Number: Twenty}
+	b.ReportAllocs()
+	dart := 0
+	for b.Loop() {
+		if dart%3 == 0 {
+			sim.StartTurn()
+		}
+		benchResult = sim.Throw(target, 20, GaussianThrowModel{})
+		dart++
+	}
+}
+
+func BenchmarkThrowWithWires(b *testing.B) {
+	sim := NewSeededSimulator(1)
+	sim.SetWireConfig(StandardWireConfig())
+	target := DartTarget{Multiplier: Triple, Number: Twenty}
+	b.ReportAllocs()
+	dart := 0
+	for b.Loop() {
+		if dart%3 == 0 {
+			sim.StartTurn()
+		}
+		benchResult = sim.Throw(target, 20, GaussianThrowModel{})
+		dart++
+	}
+}
+
+func BenchmarkThrowStudentT(b *testing.B) {
+	sim := NewSeededSimulator(1)
+	model := NewStudentTThrowModel(4)
+	target := DartTarget{Multiplier: Triple, Number: Twenty}
+	b.ReportAllocs()
+	dart := 0
+	for b.Loop() {
+		if dart%3 == 0 {
+			sim.StartTurn()
+		}
+		benchResult = sim.Throw(target, 20, model)
+		dart++
+	}
+}
+
+func BenchmarkScorePoint(b *testing.B) {
+	sim := NewSeededSimulator(1)
+	b.ReportAllocs()
+	x := 0.0
+	for b.Loop() {
+		benchResult = sim.ScorePoint(x, 103)
+		x += 0.01
+		if x > 100 {
+			x = -100
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package model
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	svgDefaultSize   = 400
+	svgSurroundScale = 1.22 // radius of the number ring relative to the scoring area
+	svgMarkerRadius  = 3.5  // mm
+	svgHeatOpacity   = 0.65
+)
+
+// Board colours: segments alternate dark and light singles, with red and green rings
+const (
+	svgDark     = "#1b1b1b"
+	svgLight    = "#efe3c2"
+	svgRed      = "#d3202a"
+	svgGreen    = "#178a3e"
+	svgWire     = "#c0c0c0"
+	svgSurround = "#111111"
+)
+
+// SVGOptions controls how a board is drawn by WriteSVG
+type SVGOptions struct {
+	Size    int       // width and height of the image in pixels; defaults to 400
+	Heatmap *Heatmap  // optional expected-score overlay, drawn over the beds and under the darts
+	Darts   []SVGDart // darts to mark, drawn in order
+}
+
+// SVGDart is a dart to mark on the board
+type SVGDart struct {
+	Result *DartResult
+	Label  string // drawn beside the marker, e.g. the dart's number in the turn
+	Faded  bool   // drawn fainter, e.g. for earlier turns of a leg
+}
+
+// WriteSVG draws the simulator's board as an SVG image, numbered in the order of Board
+// with 20 at the top, with the optional heatmap and dart markers of opts.
+// Darts scored without a landing point are marked in the middle of the bed they hit;
+// misses without a landing point are not drawn.
+func (s *Simulator) WriteSVG(w io.Writer, opts SVGOptions) error {
+	size := opts.Size
+	if size <= 0 {
+		size = svgDefaultSize
+	}
+	spec := s.spec
+	outer := spec.BoardRadius * svgSurroundScale
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%s %s %s %s">`+"\n",
+		size, size, svgNum(-outer), svgNum(-outer), svgNum(2*outer), svgNum(2*outer))
+	fmt.Fprintf(&b, `<circle cx="0" cy="0" r="%s" fill="%s"/>`+"\n", svgNum(outer), svgSurround)
+
+	// Beds: the singles and rings of each segment, from the bull outwards
+	bands := s.svgBands()
+	fmt.Fprintf(&b, `<g stroke="%s" stroke-width="0.6">`+"\n", svgWire)
+	for i := range Board {
+		centre := float64(i) * segmentAngle
+		from, to := centre-segmentAngle/2, centre+segmentAngle/2
+		for _, band := range bands {
+			fmt.Fprintf(&b, `<path d="%s" fill="%s"/>`+"\n",
+				svgSector(band.Inner, band.Outer, from, to), svgBedColour(i, band.Multiplier))
+		}
+	}
+	fmt.Fprintf(&b, `<circle cx="0" cy="0" r="%s" fill="%s"/>`+"\n", svgNum(spec.SingleBullRadius), svgGreen)
+	fmt.Fprintf(&b, `<circle cx="0" cy="0" r="%s" fill="%s"/>`+"\n", svgNum(spec.DoubleBullRadius), svgRed)
+	b.WriteString("</g>\n")
+
+	// Numbers around the outside
+	numberRadius := (spec.BoardRadius + outer) / 2
+	fontSize := (outer - spec.BoardRadius) * 0.7
+	fmt.Fprintf(&b, `<g fill="#ffffff" font-family="sans-serif" font-size="%s" text-anchor="middle" dominant-baseline="central">`+"\n",
+		svgNum(fontSize))
+	for i, number := range Board {
+		x, y := svgPolar(numberRadius, float64(i)*segmentAngle)
+		fmt.Fprintf(&b, `<text x="%s" y="%s">%d</text>`+"\n", svgNum(x), svgNum(y), number)
+	}
+	b.WriteString("</g>\n")
+
+	if opts.Heatmap != nil {
+		writeSVGHeatmap(&b, opts.Heatmap, spec.BoardRadius)
+	}
+
+	for _, d := range opts.Darts {
+		s.writeSVGDart(&b, d, fontSize)
+	}
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// svgBands returns the radial bands outside the bull, each a single bed or a ring
+func (s *Simulator) svgBands() []Ring {
+	var bands []Ring
+	inner := s.spec.SingleBullRadius
+	for _, r := range s.spec.Rings {
+		if r.Inner > inner {
+			bands = append(bands, Ring{Multiplier: Single, Inner: inner, Outer: r.Inner})
+		}
+		bands = append(bands, r)
+		inner = r.Outer
+	}
+	if s.spec.BoardRadius > inner {
+		bands = append(bands, Ring{Multiplier: Single, Inner: inner, Outer: s.spec.BoardRadius})
+	}
+	return bands
+}
+
+// svgBedColour returns the colour of a bed in the segment at the given position of Board.
+// The 20's singles are dark and its rings red, alternating around the board.
+func svgBedColour(index int, multiplier Multiplier) string {
+	dark := index%2 == 0
+	switch {
+	case multiplier == Single && dark:
+		return svgDark
+	case multiplier == Single:
+		return svgLight
+	case dark:
+		return svgRed
+	default:
+		return svgGreen
+	}
+}
+
+// writeSVGHeatmap draws the heatmap's cells within the scoring area, coloured as by WritePNG
+func writeSVGHeatmap(b *strings.Builder, h *Heatmap, radius float64) {
+	lo, hi := h.valueRange()
+	fmt.Fprintf(b, `<clipPath id="scoring-area"><circle cx="0" cy="0" r="%s"/></clipPath>`+"\n", svgNum(radius))
+	fmt.Fprintf(b, `<g opacity="%s" clip-path="url(#scoring-area)">`+"\n", svgNum(svgHeatOpacity))
+	for row, values := range h.Values {
+		for col, v := range values {
+			t := 0.0
+			if hi > lo {
+				t = (v - lo) / (hi - lo)
+			}
+			c := heatColor(t)
+			x, y := h.Point(row, col)
+			fmt.Fprintf(b, `<rect x="%s" y="%s" width="%s" height="%s" fill="#%02x%02x%02x"/>`+"\n",
+				svgNum(x-h.Resolution/2), svgNum(-y-h.Resolution/2), svgNum(h.Resolution), svgNum(h.Resolution),
+				c.R, c.G, c.B)
+		}
+	}
+	b.WriteString("</g>\n")
+}
+
+// writeSVGDart draws a dart marker and its label
+func (s *Simulator) writeSVGDart(b *strings.Builder, d SVGDart, fontSize float64) {
+	if d.Result == nil {
+		return
+	}
+	point, ok := s.getDartPoint(d.Result)
+	if !ok {
+		return
+	}
+	opacity := 1.0
+	if d.Faded {
+		opacity = 0.45
+	}
+	x, y := point.X, -point.Y
+	fill, stroke := "#ffd400", "#000000"
+	if d.Result.BounceOut {
+		fill, stroke = "none", "#ff3030"
+	}
+	fmt.Fprintf(b, `<g opacity="%s"><circle cx="%s" cy="%s" r="%s" fill="%s" stroke="%s" stroke-width="1"/>`,
+		svgNum(opacity), svgNum(x), svgNum(y), svgNum(svgMarkerRadius), fill, stroke)
+	if d.Label != "" {
+		fmt.Fprintf(b, `<text x="%s" y="%s" font-family="sans-serif" font-size="%s" fill="#ffffff" stroke="#000000" stroke-width="0.4">%s</text>`,
+			svgNum(x+svgMarkerRadius*1.3), svgNum(y-svgMarkerRadius*1.3), svgNum(fontSize*0.6), html.EscapeString(d.Label))
+	}
+	b.WriteString("</g>\n")
+}
+
+// getDartPoint returns where to mark a dart: its landing point if known, otherwise
+// the middle of the bed it hit. Returns false for a miss with no landing point.
+func (s *Simulator) getDartPoint(d *DartResult) (BoardPoint, bool) {
+	if d.Landing != (BoardPoint{}) || d.BounceOut {
+		return d.Landing, true
+	}
+	if d.GetMultiplier() == Miss {
+		return BoardPoint{}, false
+	}
+	if d.GetNumber() == Bullseye && d.GetMultiplier() == Single {
+		// The middle of the outer bull ring
+		r := (s.spec.DoubleBullRadius + s.spec.SingleBullRadius) / 2
+		return NewBoardPoint(0, r), true
+	}
+	return NewBoardPoint(s.getTargetPoint(d.DartTarget)), true
+}
+
+// svgSector returns the path of an annular sector between two radii and two angles
+// (radians clockwise from the top)
+func svgSector(inner, outer, from, to float64) string {
+	x1, y1 := svgPolar(outer, from)
+	x2, y2 := svgPolar(outer, to)
+	x3, y3 := svgPolar(inner, to)
+	x4, y4 := svgPolar(inner, from)
+	return fmt.Sprintf("M%s %sA%s %s 0 0 1 %s %sL%s %sA%s %s 0 0 0 %s %sZ",
+		svgNum(x1), svgNum(y1), svgNum(outer), svgNum(outer), svgNum(x2), svgNum(y2),
+		svgNum(x3), svgNum(y3), svgNum(inner), svgNum(inner), svgNum(x4), svgNum(y4))
+}
+
+// svgPolar converts a board radius and angle into SVG coordinates, where y grows downwards
+func svgPolar(radius, angle float64) (float64, float64) {
+	return radius * math.Sin(angle), -radius * math.Cos(angle)
+}
+
+// svgNum formats a coordinate compactly
+func svgNum(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,212 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	ansiDefaultWidth = 41
+	ansiSupersample  = 3 // samples per pixel on each axis
+	ansiReset        = "\x1b[0m"
+)
+
+// 256-colour palette indices for the board
+const (
+	ansiDark     = 234
+	ansiLight    = 223
+	ansiRed      = 160
+	ansiGreen    = 28
+	ansiSurround = 232
+	ansiNumber   = 255
+	ansiDart     = 226
+	ansiBounced  = 201
+)
+
+// ANSIOptions controls how a board is drawn by WriteANSI
+type ANSIOptions struct {
+	Width int           // columns, and pixels on each axis; defaults to 41
+	Darts []*DartResult // darts to highlight, labelled 1, 2, 3... in order
+}
+
+// ansiPixel is the bed drawn at one pixel, in increasing order of priority
+type ansiPixel int
+
+const (
+	ansiPixelSurround ansiPixel = iota
+	ansiPixelSingle
+	ansiPixelSingleBull
+	ansiPixelRing
+	ansiPixelDoubleBull
+)
+
+// WriteANSI draws the simulator's board for a terminal with 256-colour support, using
+// half-block characters so that each line holds two rows of square pixels.
+// The numbers are written around the outside and the darts of opts are highlighted
+// with their labels; bounce-outs are drawn in a different colour.
+func (s *Simulator) WriteANSI(w io.Writer, opts ANSIOptions) error {
+	_, err := io.WriteString(w, strings.Join(s.RenderANSI(opts), "\n")+"\n")
+	return err
+}
+
+// RenderANSI is WriteANSI returning the board line by line, e.g. to place it beside other text.
+// Every line has the same visible width.
+func (s *Simulator) RenderANSI(opts ANSIOptions) []string {
+	size := opts.Width
+	if size <= 0 {
+		size = ansiDefaultWidth
+	}
+	if size%2 == 0 {
+		// An odd size puts the bull in the middle of a pixel
+		size++
+	}
+	outer := s.spec.BoardRadius * svgSurroundScale
+	pixel := 2 * outer / float64(size)
+
+	// Colour of every pixel, row 0 at the top
+	colours := make([][]int, size)
+	for row := range colours {
+		colours[row] = make([]int, size)
+		for col := range colours[row] {
+			colours[row][col] = s.ansiPixelColour(row, col, pixel, outer)
+		}
+	}
+
+	// Text laid over whole cells: numbers around the outside and dart labels
+	lines := (size + 1) / 2
+	text := make([][]ansiText, lines)
+	for line := range text {
+		text[line] = make([]ansiText, size)
+	}
+	numberRadius := (s.spec.BoardRadius + outer) / 2
+	for i, number := range Board {
+		x, y := numberRadius*math.Sin(float64(i)*segmentAngle), numberRadius*math.Cos(float64(i)*segmentAngle)
+		row, col := ansiCell(x, y, pixel, outer)
+		label := strconv.Itoa(number)
+		// Centre two-digit numbers on the point
+		col -= (len(label) - 1) / 2
+		for j, c := range label {
+			if line := row / 2; line >= 0 && line < lines && col+j >= 0 && col+j < size {
+				text[line][col+j] = ansiText{char: c, fg: ansiNumber, bg: ansiSurround}
+			}
+		}
+	}
+	for i, d := range opts.Darts {
+		point, ok := s.getDartPoint(d)
+		if !ok {
+			continue
+		}
+		row, col := ansiCell(point.X, point.Y, pixel, outer)
+		line := row / 2
+		if line < 0 || line >= lines || col < 0 || col >= size {
+			continue
+		}
+		colour := ansiDart
+		if d.BounceOut {
+			colour = ansiBounced
+		}
+		text[line][col] = ansiText{char: rune('0' + (i+1)%10), fg: ansiSurround, bg: colour, bold: true}
+	}
+
+	out := make([]string, lines)
+	for line := range out {
+		var b strings.Builder
+		fg, bg, bold := -1, -1, false
+		for col := range size {
+			cell := text[line][col]
+			if cell.char == 0 {
+				// Upper half block: foreground is the top pixel, background the bottom one
+				cell = ansiText{char: '▀', fg: colours[2*line][col], bg: ansiSurround}
+				if 2*line+1 < size {
+					cell.bg = colours[2*line+1][col]
+				}
+			}
+			// Only change the colours when they differ from the previous cell
+			if cell.bold != bold {
+				b.WriteString(ansiReset)
+				fg, bg, bold = -1, -1, cell.bold
+				if bold {
+					b.WriteString("\x1b[1m")
+				}
+			}
+			if cell.fg != fg || cell.bg != bg {
+				fmt.Fprintf(&b, "\x1b[38;5;%d;48;5;%dm", cell.fg, cell.bg)
+				fg, bg = cell.fg, cell.bg
+			}
+			b.WriteRune(cell.char)
+		}
+		b.WriteString(ansiReset)
+		out[line] = b.String()
+	}
+	return out
+}
+
+// ansiText is a character drawn over a whole cell
+type ansiText struct {
+	char rune // zero for none
+	fg   int
+	bg   int
+	bold bool
+}
+
+// ansiPixelColour returns the colour of a pixel. Each pixel is supersampled and takes
+// the colour of its highest-priority bed, so that the narrow rings stay unbroken.
+func (s *Simulator) ansiPixelColour(row, col int, pixel, outer float64) int {
+	best, colour := ansiPixelSurround, ansiSurround
+	for i := range ansiSupersample {
+		for j := range ansiSupersample {
+			x := -outer + (float64(col)+(float64(j)+0.5)/ansiSupersample)*pixel
+			y := outer - (float64(row)+(float64(i)+0.5)/ansiSupersample)*pixel
+			radius := math.Sqrt(x*x + y*y)
+			if radius > s.spec.BoardRadius {
+				continue
+			}
+			bed := s.tables.bedAt(s.spec, radius)
+			kind := ansiPixelSingle
+			switch {
+			case bed.bull && bed.multiplier == Double:
+				kind = ansiPixelDoubleBull
+			case bed.bull:
+				kind = ansiPixelSingleBull
+			case bed.multiplier != Single:
+				kind = ansiPixelRing
+			}
+			if kind <= best {
+				continue
+			}
+			best = kind
+			switch kind {
+			case ansiPixelDoubleBull:
+				colour = ansiRed
+			case ansiPixelSingleBull:
+				colour = ansiGreen
+			default:
+				colour = ansiBedColour(s.tables.numberAt(math.Atan2(x, y)), bed.multiplier)
+			}
+		}
+	}
+	return colour
+}
+
+// ansiBedColour returns the colour of a bed, alternating around the board as in svgBedColour
+func ansiBedColour(number int, multiplier Multiplier) int {
+	dark := boardIndex(number)%2 == 0
+	switch {
+	case multiplier == Single && dark:
+		return ansiDark
+	case multiplier == Single:
+		return ansiLight
+	case dark:
+		return ansiRed
+	default:
+		return ansiGreen
+	}
+}
+
+// ansiCell returns the pixel row and column containing the board point (x, y)
+func ansiCell(x, y, pixel, outer float64) (int, int) {
+	return int(math.Floor((outer - y) / pixel)), int(math.Floor((x + outer) / pixel))
+}
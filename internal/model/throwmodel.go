@@ -0,0 +1,139 @@
+package model
+
+import (
+	"math"
+	"math/rand"
+)
+
+// ThrowModel describes how a dart disperses around the point it was aimed at.
+// Sample returns the landing offset (in mm) from the aim point, where x is
+// horizontal (positive to the right) and y is vertical (positive up).
+// spread is the player's overall dispersion in mm, as derived from their 3DA.
+type ThrowModel interface {
+	Sample(rng *rand.Rand, spread float64) (dx, dy float64)
+}
+
+// GaussianThrowModel is an isotropic 2D Gaussian with standard deviation spread on both axes.
+type GaussianThrowModel struct{}
+
+func (GaussianThrowModel) Sample(rng *rand.Rand, spread float64) (float64, float64) {
+	return rng.NormFloat64() * spread, rng.NormFloat64() * spread
+}
+
+// BivariateGaussianThrowModel is a 2D Gaussian with independent horizontal and
+// vertical scaling and a correlation between the two axes.
+// The scales multiply spread, so the player's 3DA still controls the overall size.
+type BivariateGaussianThrowModel struct {
+	HorizontalScale float64 // sigma_x = spread * HorizontalScale
+	VerticalScale   float64 // sigma_y = spread * VerticalScale
+	Correlation     float64 // in (-1, 1)
+}
+
+// NewBivariateGaussianThrowModel creates a bivariate Gaussian throw model.
+// Correlation is clamped into (-1, 1).
+func NewBivariateGaussianThrowModel(horizontalScale, verticalScale, correlation float64) *BivariateGaussianThrowModel {
+	return &BivariateGaussianThrowModel{
+		HorizontalScale: horizontalScale,
+		VerticalScale:   verticalScale,
+		Correlation:     math.Max(-0.99, math.Min(0.99, correlation)),
+	}
+}
+
+func (m *BivariateGaussianThrowModel) Sample(rng *rand.Rand, spread float64) (float64, float64) {
+	z1 := rng.NormFloat64()
+	z2 := rng.NormFloat64()
+	dx := z1 * spread * m.HorizontalScale
+	dy := (m.Correlation*z1 + math.Sqrt(1-m.Correlation*m.Correlation)*z2) * spread * m.VerticalScale
+	return dx, dy
+}
+
+// StudentTThrowModel is a heavy-tailed bivariate Student-t distribution.
+// Lower DegreesOfFreedom give more wild darts; as it grows it approaches the Gaussian.
+type StudentTThrowModel struct {
+	DegreesOfFreedom float64
+}
+
+// NewStudentTThrowModel creates a Student-t throw model. DegreesOfFreedom must be positive.
+func NewStudentTThrowModel(degreesOfFreedom float64) *StudentTThrowModel {
+	if degreesOfFreedom <= 0 {
+		degreesOfFreedom = 1
+	}
+	return &StudentTThrowModel{DegreesOfFreedom: degreesOfFreedom}
+}
+
+func (m *StudentTThrowModel) Sample(rng *rand.Rand, spread float64) (float64, float64) {
+	// A bivariate t is a Gaussian whose scale is divided by sqrt(chi2/nu);
+	// sharing the chi-squared draw across both axes keeps the distribution round.
+	chi2 := 2.0 * sampleGamma(rng, m.DegreesOfFreedom/2.0)
+	scale := spread / math.Sqrt(chi2/m.DegreesOfFreedom)
+	return rng.NormFloat64() * scale, rng.NormFloat64() * scale
+}
+
+// MixtureComponent is one weighted component of a MixtureThrowModel.
+type MixtureComponent struct {
+	Weight float64
+	Model  ThrowModel
+	Scale  float64 // multiplies spread for this component
+}
+
+// MixtureThrowModel picks one component per dart, weighted by Weight, and samples from it.
+type MixtureThrowModel struct {
+	Components  []MixtureComponent
+	totalWeight float64
+}
+
+// NewMixtureThrowModel creates a mixture from the given components.
+func NewMixtureThrowModel(components ...MixtureComponent) *MixtureThrowModel {
+	m := &MixtureThrowModel{Components: components}
+	for _, c := range components {
+		m.totalWeight += c.Weight
+	}
+	return m
+}
+
+// NewGoodBadThrowModel creates the common "good dart"/"bad dart" mixture: most darts
+// are Gaussian at the player's spread, and with probability badProbability the dart
+// is Gaussian with spread scaled by badScale.
+func NewGoodBadThrowModel(badProbability, badScale float64) *MixtureThrowModel {
+	return NewMixtureThrowModel(
+		MixtureComponent{Weight: 1 - badProbability, Model: GaussianThrowModel{}, Scale: 1},
+		MixtureComponent{Weight: badProbability, Model: GaussianThrowModel{}, Scale: badScale},
+	)
+}
+
+func (m *MixtureThrowModel) Sample(rng *rand.Rand, spread float64) (float64, float64) {
+	if len(m.Components) == 0 {
+		return GaussianThrowModel{}.Sample(rng, spread)
+	}
+	pick := rng.Float64() * m.totalWeight
+	for _, c := range m.Components {
+		if pick < c.Weight {
+			return c.Model.Sample(rng, spread*c.Scale)
+		}
+		pick -= c.Weight
+	}
+	last := m.Components[len(m.Components)-1]
+	return last.Model.Sample(rng, spread*last.Scale)
+}
+
+// sampleGamma draws from Gamma(shape, 1) using Marsaglia and Tsang's method
+func sampleGamma(rng *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		// Boost to shape+1 and scale back down
+		return sampleGamma(rng, shape+1) * math.Pow(rng.Float64(), 1.0/shape)
+	}
+	d := shape - 1.0/3.0
+	c := 1.0 / math.Sqrt(9.0*d)
+	for {
+		x := rng.NormFloat64()
+		v := 1.0 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rng.Float64()
+		if u < 1.0-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1.0-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package model
+
+import "math"
+
+// WireConfig controls how the simulator treats the wires and darts already in the board.
+// The zero value reproduces the idealised board: infinitely thin wires, no bounce-outs
+// and no obstruction from earlier darts.
+type WireConfig struct {
+	Thickness       float64 // full width of the spider wires in mm
+	BounceOutChance float64 // probability that a dart striking a wire bounces out
+	DartRadius      float64 // radius in mm around an earlier dart in the same turn that blocks later darts
+	ShadowBounceOut float64 // probability that a dart striking an earlier dart bounces out rather than deflecting
+}
+
+// StandardWireConfig returns wire settings typical of a modern steel-tip board
+func StandardWireConfig() WireConfig {
+	return WireConfig{
+		Thickness:       1.2,
+		BounceOutChance: 0.12,
+		DartRadius:      3.5,
+		ShadowBounceOut: 0.3,
+	}
+}
+
+// SetWireConfig configures wire thickness, bounce-outs and dart shadowing.
+// Only darts thrown between StartTurn and EndTurn obstruct each other.
+func (s *Simulator) SetWireConfig(cfg WireConfig) {
+	s.wires = cfg
+}
+
+// GetWireConfig returns the simulator's wire configuration
+func (s *Simulator) GetWireConfig() WireConfig {
+	return s.wires
+}
+
+// StartTurn begins a turn with an empty board. Until EndTurn, every dart that sticks is
+// recorded, so it can obstruct the turn's later darts and be reported by IsBlocked.
+// Darts thrown outside a turn are not recorded.
+func (s *Simulator) StartTurn() {
+	s.turnDarts = s.turnDarts[:0]
+	s.inTurn = true
+}
+
+// EndTurn ends the turn begun by StartTurn and clears its darts from the board
+func (s *Simulator) EndTurn() {
+	s.turnDarts = s.turnDarts[:0]
+	s.inTurn = false
+}
+
+// IsBlocked reports whether a dart thrown since StartTurn sits over the centre of target,
+// within the dart radius of the wire configuration or of a standard dart if shadowing is off.
+// Outside a turn nothing is blocked.
+func (s *Simulator) IsBlocked(target DartTarget) bool {
+	radius := s.wires.DartRadius
+	if radius <= 0 {
+		radius = StandardWireConfig().DartRadius
+	}
+	x, y := s.getTargetPoint(target)
+	for _, d := range s.turnDarts {
+		if math.Hypot(d[0]-x, d[1]-y) < radius {
+			return true
+		}
+	}
+	return false
+}
+
+// bounceOut returns the result for a dart that bounced out of the board
+func bounceOut() DartResult {
+	return DartResult{
+		DartTarget: DartTarget{
+			Number:     0,
+			Multiplier: Miss,
+		},
+		Score:     0,
+		BounceOut: true,
+	}
+}
+
+// applyShadow checks whether a dart landing at (x, y) strikes a dart already in the board.
+// It returns the (possibly deflected) landing point and whether the dart bounced out.
+func (s *Simulator) applyShadow(x, y float64) (float64, float64, bool) {
+	if s.wires.DartRadius <= 0 {
+		return x, y, false
+	}
+	for _, d := range s.turnDarts {
+		dx := x - d[0]
+		dy := y - d[1]
+		dist := math.Sqrt(dx*dx + dy*dy)
+		if dist >= s.wires.DartRadius {
+			continue
+		}
+		if s.rng.Float64() < s.wires.ShadowBounceOut {
+			return x, y, true
+		}
+		// Deflect off the earlier dart to the edge of its shadow
+		if dist == 0 {
+			dx, dy, dist = 0, -1, 1
+		}
+		scale := s.wires.DartRadius / dist
+		return d[0] + dx*scale, d[1] + dy*scale, false
+	}
+	return x, y, false
+}
+
+// hitsWire reports whether a dart landing at the given polar position strikes a wire
+func (s *Simulator) hitsWire(radius, angle float64) bool {
+	half := s.wires.Thickness / 2.0
+	if half <= 0 || radius > s.spec.BoardRadius+half {
+		return false
+	}
+
+	// Ring wires
+	for _, r := range s.tables.wireRadii {
+		if math.Abs(radius-r) <= half {
+			return true
+		}
+	}
+
+	// Radial wires between segments only run outside the bull
+	if radius < s.spec.SingleBullRadius || radius > s.spec.BoardRadius {
+		return false
+	}
+	pos := angle/segmentAngle + 0.5
+	offset := math.Abs(pos-math.Round(pos)) * segmentAngle
+	return radius*math.Sin(offset) <= half
+}
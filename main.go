@@ -1,31 +1,140 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"github.com/kregan77/dartbuddy/internal/model"
+	"github.com/kregan77/dartbuddy/internal/model/oh1"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
 )
 
 func main() {
-	// The main function is intentionally left empty.
-	g := model.New01Game(401)
-	g.AddPlayer(model.NewPlayer("Alice", 32.0, model.TwentiesScoringPreference))
-	g.AddPlayer(model.NewPlayer("Anthony", 50.0, model.TwentiesScoringPreference))
+	seed := flag.Int64("seed", 0, "simulator seed for a reproducible game (0 picks a random seed)")
+	batch := flag.Int("batch", 0, "simulate this many legs (or matches) in parallel and print statistics")
+	legsToWin := flag.Int("legs", 1, "legs needed to win a match in batch mode")
+	plain := flag.Bool("plain", false, "narrate the game as plain text instead of drawing the board and scoreboard")
+	delay := flag.Duration("delay", 500*time.Millisecond, "pause between turns when drawing the board; none with -plain unless set")
+	playerOuts := flag.Bool("player-outs", false, "give each player checkouts tailored to their accuracy")
+	flag.Parse()
+
+	players := []*model.PlayerProfile{
+		model.NewPlayer("Alice", 32.0, model.TwentiesScoringPreference),
+		model.NewPlayer("Anthony", 50.0, model.TwentiesScoringPreference),
+	}
+	for _, p := range players {
+		p.PlayerType = model.SimulatedPlayer
+	}
+
+	if *plain && !isFlagSet("delay") {
+		*delay = 0
+	}
+
+	if *batch > 0 {
+		runBatch(players, *batch, *legsToWin, *seed, *playerOuts)
+		return
+	}
+
+	g := oh1.New01Game(401)
+	if *seed != 0 {
+		g = oh1.New01GameWithSeed(401, *seed)
+	}
+	if *plain {
+		g.AddObserver(oh1.NewPrinter(os.Stdout))
+		fmt.Printf("Game seed: %d\n\n", g.Seed)
+	} else {
+		g.AddObserver(oh1.NewScoreboard(os.Stdout, g))
+	}
+	for _, p := range players {
+		player := g.AddPlayer(p)
+		if *playerOuts {
+			g.UsePlayerOuts(player)
+		}
+	}
 	g.Start()
-	for _ = range 50 {
-		r := g.PlayTurn()
-		switch r.Type {
-		case model.WinTurn:
-			fmt.Printf("Player %s scored %d points for the win(3DA: %.2f)!\n\n",
-				r.PlayerName, r.TotalScore, r.CurrentThreeDA)
-			fmt.Println(g.GetGameSummary())
-			return
-		case model.BustTurn:
-			fmt.Printf("Player %s busted\n\n", r.PlayerName)
-		case model.NormalTurn:
-			fmt.Printf("Player %s scored %d points(3DA: %.2f) remaining score: %d\n\n",
-				r.PlayerName, r.TotalScore,
-				r.CurrentThreeDA, r.RemainingScore)
+	finished := false
+	for range 50 {
+		r, err := g.PlayTurn()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if r.Type == oh1.WinTurn {
+			finished = true
+			break
+		}
+		time.Sleep(*delay)
+	}
+	switch {
+	case !finished:
+		fmt.Printf("\nNobody finished in 50 turns (game seed: %d)\n", g.Seed)
+	case *plain:
+		fmt.Println(g.GetGameSummary())
+	default:
+		fmt.Printf("\nGame seed: %d\n", g.Seed)
+	}
+}
+
+// isFlagSet reports whether the named flag was given on the command line
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// runBatch simulates many legs between the players and prints the aggregate statistics
+func runBatch(players []*model.PlayerProfile, runs, legsToWin int, seed int64, playerOuts bool) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	fmt.Printf("Batch seed: %d\n", seed)
+
+	result, err := oh1.RunBatch(ctx, oh1.BatchConfig{
+		Runs:       runs,
+		LegsToWin:  legsToWin,
+		StartScore: 401,
+		Players:    players,
+		Seed:       seed,
+		PlayerOuts: playerOuts,
+		Progress: func(done, total int) {
+			if done%1000 == 0 || done == total {
+				fmt.Fprintf(os.Stderr, "\r%d/%d", done, total)
+			}
+		},
+	})
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		fmt.Printf("Stopped early: %v\n", err)
+	}
+
+	fmt.Printf("%d runs, %d legs\n\n", result.Runs, result.Legs)
+	for _, p := range result.Players {
+		fmt.Printf("%s:\n", p.Name)
+		fmt.Printf("\tWin probability: %.1f%% (%.1f-%.1f)\n",
+			p.WinProbability.Mean*100, p.WinProbability.Low*100, p.WinProbability.High*100)
+		fmt.Printf("\tDarts per leg won: %.1f (%.1f-%.1f)\n",
+			p.DartsPerLeg.Mean, p.DartsPerLeg.Low, p.DartsPerLeg.High)
+		fmt.Printf("\t3DA: %.2f (%.2f-%.2f)\n", p.ThreeDA.Mean, p.ThreeDA.Low, p.ThreeDA.High)
+		fmt.Printf("\tCheckout: %.1f%% (%.1f-%.1f)\n",
+			p.CheckoutPercent.Mean, p.CheckoutPercent.Low, p.CheckoutPercent.High)
+		fmt.Printf("\t180s: %d (%.3f per leg)\n", p.OneEighties, p.OneEightiesLeg.Mean)
+
+		darts := make([]int, 0, len(p.DartsHistogram))
+		for d := range p.DartsHistogram {
+			darts = append(darts, d)
+		}
+		sort.Ints(darts)
+		if len(darts) > 0 {
+			fmt.Printf("\tFastest leg: %d darts, slowest: %d darts\n", darts[0], darts[len(darts)-1])
 		}
 	}
 }